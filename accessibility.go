@@ -0,0 +1,98 @@
+package google
+
+import (
+	"encoding/json"
+)
+
+// AccessibilityFinding is a failed accessibility audit with the affected DOM nodes.
+type AccessibilityFinding struct {
+	Audit  *Audit       `json:"audit"`
+	Group  string       `json:"group,omitempty"`  // The group of the audit (eg.: "a11y-color-contrast"), empty if the audit is not grouped
+	Impact string       `json:"impact,omitempty"` // The impact of the issue reported by axe (eg.: "serious")
+	Tags   []string     `json:"tags,omitempty"`   // The axe tags, including the WCAG criteria (eg.: "wcag2aa", "wcag143")
+	Nodes  []*NodeValue `json:"nodes"`            // The affected DOM nodes
+}
+
+// AccessibilityGroup is a group of the failed accessibility audits.
+type AccessibilityGroup struct {
+	ID          string                  `json:"id"` // eg.: "a11y-color-contrast"
+	Title       string                  `json:"title,omitempty"`
+	Description string                  `json:"description,omitempty"`
+	Findings    []*AccessibilityFinding `json:"findings"`
+	Elements    int                     `json:"elements"` // The number of affected elements
+}
+
+// AccessibilityReport is the failed accessibility audits grouped by the audit groups.
+type AccessibilityReport struct {
+	Score    NullScore             `json:"score"`
+	Groups   []*AccessibilityGroup `json:"groups"`   // In the order of the audits in the category
+	Elements int                   `json:"elements"` // The total number of affected elements
+}
+
+// auditFailed returns whether the audit is scored and failed.
+func auditFailed(a *Audit) bool {
+
+	switch a.ScoreDisplayMode {
+	case "notApplicable", "manual", "informative", "error":
+		return false
+	}
+
+	s := a.Score
+
+	return s.Valid && s.Value < passThreshold
+}
+
+// Accessibility returns the failed audits of the accessibility category with the affected nodes.
+//
+// If the accessibility category is missing, returns nil.
+func (r *LighthouseResult) Accessibility() *AccessibilityReport {
+
+	c := r.categories[string(CategoryAccessibility)]
+	if c == nil {
+		return nil
+	}
+
+	v := &AccessibilityReport{Score: c.Score}
+
+	groups := make(map[string]*AccessibilityGroup)
+
+	for _, ref := range c.AuditRefs {
+
+		a := r.audits[ref.ID]
+		if a == nil || !auditFailed(a) {
+			continue
+		}
+
+		f := &AccessibilityFinding{Audit: a, Group: ref.Group}
+
+		if d, ok := a.Details.(*TableDetails); ok {
+
+			f.Nodes = d.Nodes()
+
+			if d.DebugData != nil {
+				_ = json.Unmarshal(d.DebugData.Data["impact"], &f.Impact)
+				_ = json.Unmarshal(d.DebugData.Data["tags"], &f.Tags)
+			}
+		}
+
+		g := groups[ref.Group]
+		if g == nil {
+
+			g = &AccessibilityGroup{ID: ref.Group}
+
+			if cg := r.categoryGroups[ref.Group]; cg != nil {
+				g.Title = cg.Title
+				g.Description = cg.Description
+			}
+
+			groups[ref.Group] = g
+			v.Groups = append(v.Groups, g)
+		}
+
+		g.Findings = append(g.Findings, f)
+		g.Elements += len(f.Nodes)
+		v.Elements += len(f.Nodes)
+	}
+
+	return v
+}
@@ -0,0 +1,70 @@
+package google_test
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAccessibility(t *testing.T) {
+
+	v := readTestResult(t, false).Accessibility()
+
+	if v == nil || len(v.Groups) != 2 || v.Elements != 3 {
+		t.Fatalf("FAIL: invalid report: %#v\n", v)
+	}
+
+	g := v.Groups[0]
+	if g.ID != "a11y-color-contrast" || g.Title != "Contrast" || g.Elements != 2 || len(g.Findings) != 1 {
+		t.Fatalf("FAIL: invalid first group: %#v\n", g)
+	}
+
+	f := g.Findings[0]
+	if f.Audit.ID != "color-contrast" || f.Impact != "serious" || len(f.Tags) != 3 || f.Tags[1] != "wcag2aa" {
+		t.Fatalf("FAIL: invalid finding: %s %s %v\n", f.Audit.ID, f.Impact, f.Tags)
+	}
+
+	if f.Nodes[0].Selector != "body > p.note" || f.Nodes[0].Snippet != `<p class="note">` || f.Nodes[0].NodeLabel != "Note text" || f.Nodes[0].Explanation == "" {
+		t.Fatalf("FAIL: invalid node: %#v\n", f.Nodes[0])
+	}
+
+	if g = v.Groups[1]; g.ID != "a11y-names-labels" || g.Elements != 1 || g.Findings[0].Nodes[0].Selector != "body > img" {
+		t.Fatalf("FAIL: invalid second group: %#v\n", g)
+	}
+}
+
+func TestAccessibilityMarshalJSON(t *testing.T) {
+
+	data, err := json.Marshal(readTestResult(t, false).Accessibility())
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	v := struct {
+		Groups []struct {
+			Findings []struct {
+				Audit struct {
+					ID string `json:"id"`
+				} `json:"audit"`
+				Group  string   `json:"group"`
+				Impact string   `json:"impact"`
+				Tags   []string `json:"tags"`
+				Nodes  []struct {
+					Selector string `json:"selector"`
+				} `json:"nodes"`
+			} `json:"findings"`
+		} `json:"groups"`
+	}{}
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if len(v.Groups) != 2 || len(v.Groups[0].Findings) != 1 {
+		t.Fatalf("FAIL: invalid JSON: %s\n", data)
+	}
+
+	f := v.Groups[0].Findings[0]
+	if f.Audit.ID != "color-contrast" || f.Group != "a11y-color-contrast" || f.Impact != "serious" || len(f.Tags) != 3 || len(f.Nodes) != 2 || f.Nodes[0].Selector != "body > p.note" {
+		t.Fatalf("FAIL: invalid finding JSON: %#v\n", f)
+	}
+}
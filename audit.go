@@ -0,0 +1,470 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Possible values of the type field of the audit details
+const (
+	DetailsTypeTable                = "table"
+	DetailsTypeOpportunity          = "opportunity"
+	DetailsTypeList                 = "list"
+	DetailsTypeCriticalRequestChain = "criticalrequestchain"
+	DetailsTypeScreenshot           = "screenshot"
+	DetailsTypeFilmstrip            = "filmstrip"
+	DetailsTypeTreemapData          = "treemap-data"
+	DetailsTypeDebugData            = "debugdata"
+)
+
+// AuditDetails is the details object of an Audit.
+//
+// The type of the details is one of *TableDetails, *OpportunityDetails, *ListDetails, *CriticalRequestChainDetails,
+// *ScreenshotDetails, *FilmstripDetails, *TreemapDataDetails, *DebugDataDetails or *UnknownDetails.
+//
+// See: https://github.com/GoogleChrome/lighthouse/blob/main/types/lhr/audit-details.d.ts
+type AuditDetails interface {
+	DetailsType() string
+}
+
+// TableHeading describes a column of a table.
+type TableHeading struct {
+	Key             string        `json:"key"`
+	ValueType       string        `json:"valueType,omitempty"` // eg.: "url", "bytes", "ms", "node", "source-location"
+	Label           string        `json:"label,omitempty"`
+	Granularity     float64       `json:"granularity,omitempty"`
+	DisplayUnit     string        `json:"displayUnit,omitempty"`
+	SubItemsHeading *TableHeading `json:"subItemsHeading,omitempty"`
+}
+
+// UnmarshalJSON handles the older opportunity headings (itemType and text instead of valueType and label).
+func (h *TableHeading) UnmarshalJSON(data []byte) error {
+
+	type heading TableHeading
+
+	v := struct {
+		heading
+		ItemType string `json:"itemType"`
+		Text     string `json:"text"`
+	}{}
+
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+
+	*h = TableHeading(v.heading)
+
+	if h.ValueType == "" {
+		h.ValueType = v.ItemType
+	}
+
+	if h.Label == "" {
+		h.Label = v.Text
+	}
+
+	return nil
+}
+
+// DetailsItem is a row of a table or an opportunity.
+//
+// The values are decoded on request, because the type of the value depends on the heading (see [TableHeading.ValueType]).
+type DetailsItem map[string]json.RawMessage
+
+// NodeValue is a DOM node in a DetailsItem.
+type NodeValue struct {
+	LhId         string `json:"lhId,omitempty"`
+	Path         string `json:"path,omitempty"`
+	Selector     string `json:"selector,omitempty"`
+	Snippet      string `json:"snippet,omitempty"`
+	NodeLabel    string `json:"nodeLabel,omitempty"`
+	Explanation  string `json:"explanation,omitempty"`
+	BoundingRect *Rect  `json:"boundingRect,omitempty"`
+}
+
+// Rect is the bounding rectangle of a node.
+type Rect struct {
+	Top    float64 `json:"top"`
+	Bottom float64 `json:"bottom"`
+	Left   float64 `json:"left"`
+	Right  float64 `json:"right"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// SourceLocationValue is a location in a source file in a DetailsItem.
+type SourceLocationValue struct {
+	URL         string `json:"url"`
+	URLProvider string `json:"urlProvider,omitempty"`
+	Line        int    `json:"line"`
+	Column      int    `json:"column"`
+}
+
+// String returns the string value of key.
+//
+// If the value is missing or not a string, returns an empty string.
+func (i DetailsItem) String(key string) string {
+
+	var v string
+
+	_ = json.Unmarshal(i[key], &v)
+
+	return v
+}
+
+// Float returns the number value of key.
+//
+// If the value is missing or not a number, returns 0.
+func (i DetailsItem) Float(key string) float64 {
+
+	var v float64
+
+	_ = json.Unmarshal(i[key], &v)
+
+	return v
+}
+
+// Node returns the node value of key.
+//
+// If the value is missing or not a node, returns nil.
+func (i DetailsItem) Node(key string) *NodeValue {
+
+	v := struct {
+		Type string `json:"type"`
+		NodeValue
+	}{}
+
+	if err := json.Unmarshal(i[key], &v); err != nil || v.Type != "node" {
+		return nil
+	}
+
+	return &v.NodeValue
+}
+
+// SourceLocation returns the source location value of key.
+//
+// If the value is missing or not a source location, returns nil.
+func (i DetailsItem) SourceLocation(key string) *SourceLocationValue {
+
+	v := struct {
+		Type string `json:"type"`
+		SourceLocationValue
+	}{}
+
+	if err := json.Unmarshal(i[key], &v); err != nil || v.Type != "source-location" {
+		return nil
+	}
+
+	return &v.SourceLocationValue
+}
+
+// SubItems returns the items of the subItems value.
+//
+// If subItems is missing, returns nil.
+func (i DetailsItem) SubItems() []DetailsItem {
+
+	v := struct {
+		Items []DetailsItem `json:"items"`
+	}{}
+
+	_ = json.Unmarshal(i["subItems"], &v)
+
+	return v.Items
+}
+
+// TableDetails is a table of items.
+type TableDetails struct {
+	Headings        []TableHeading    `json:"headings"`
+	Items           []DetailsItem     `json:"items"`
+	Summary         *Savings          `json:"summary,omitempty"`
+	SortedBy        []string          `json:"sortedBy,omitempty"`
+	IsEntityGrouped bool              `json:"isEntityGrouped,omitempty"`
+	DebugData       *DebugDataDetails `json:"debugData,omitempty"` // eg.: the impact and the tags of the accessibility audits
+}
+
+// Nodes returns the node values of the items, in the order of the items and the node headings.
+func (d *TableDetails) Nodes() []*NodeValue {
+
+	var v []*NodeValue
+
+	for i := range d.Items {
+		for j := range d.Headings {
+
+			if d.Headings[j].ValueType != "node" {
+				continue
+			}
+
+			if n := d.Items[i].Node(d.Headings[j].Key); n != nil {
+				v = append(v, n)
+			}
+		}
+	}
+
+	return v
+}
+
+// Savings are the estimated savings.
+type Savings struct {
+	WastedMs    float64 `json:"wastedMs,omitempty"`
+	WastedBytes float64 `json:"wastedBytes,omitempty"`
+}
+
+// OpportunityDetails is a table of items with the estimated savings.
+type OpportunityDetails struct {
+	Headings            []TableHeading    `json:"headings"`
+	Items               []DetailsItem     `json:"items"`
+	OverallSavingsMs    float64           `json:"overallSavingsMs"`
+	OverallSavingsBytes float64           `json:"overallSavingsBytes,omitempty"`
+	SortedBy            []string          `json:"sortedBy,omitempty"`
+	IsEntityGrouped     bool              `json:"isEntityGrouped,omitempty"`
+	DebugData           *DebugDataDetails `json:"debugData,omitempty"`
+}
+
+// ListDetails is a list of details (eg.: tables).
+type ListDetails struct {
+	Items []AuditDetails `json:"items"`
+}
+
+// CriticalRequest is a request in the critical request chain.
+type CriticalRequest struct {
+	URL                  string  `json:"url"`
+	StartTime            float64 `json:"startTime"`
+	EndTime              float64 `json:"endTime"`
+	ResponseReceivedTime float64 `json:"responseReceivedTime"`
+	TransferSize         float64 `json:"transferSize"`
+}
+
+// CriticalRequestNode is a node of the critical request chain.
+type CriticalRequestNode struct {
+	Request  CriticalRequest                 `json:"request"`
+	Children map[string]*CriticalRequestNode `json:"children,omitempty"`
+}
+
+// CriticalRequestChainDetails are the chains of the critical requests.
+type CriticalRequestChainDetails struct {
+	Chains       map[string]*CriticalRequestNode `json:"chains"`
+	LongestChain struct {
+		Duration     float64 `json:"duration"`
+		Length       int     `json:"length"`
+		TransferSize float64 `json:"transferSize"`
+	} `json:"longestChain"`
+}
+
+// ScreenshotDetails is a screenshot as a base64 encoded data URL.
+//
+// Data is empty, unless the screenshots are requested (see [ResponseDecoder.KeepScreenshots]).
+type ScreenshotDetails struct {
+	Timing    float64 `json:"timing"`
+	Timestamp float64 `json:"timestamp"`
+	Data      string  `json:"data"`
+}
+
+// FilmstripFrame is a frame of the filmstrip.
+type FilmstripFrame struct {
+	Timing    float64 `json:"timing"`
+	Timestamp float64 `json:"timestamp"`
+	Data      string  `json:"data"`
+}
+
+// FilmstripDetails are the screenshots taken during the page load.
+//
+// The Data of the frames is empty, unless the screenshots are requested (see [ResponseDecoder.KeepScreenshots]).
+type FilmstripDetails struct {
+	Scale float64          `json:"scale"`
+	Items []FilmstripFrame `json:"items"`
+}
+
+// TreemapNode is a node of the treemap (eg.: a script or a module in a bundle).
+type TreemapNode struct {
+	Name          string         `json:"name"`
+	ResourceBytes float64        `json:"resourceBytes"`
+	UnusedBytes   float64        `json:"unusedBytes,omitempty"`
+	EncodedBytes  float64        `json:"encodedBytes,omitempty"`
+	Duplicate     string         `json:"duplicatedNormalizedModuleName,omitempty"`
+	Children      []*TreemapNode `json:"children,omitempty"`
+}
+
+// TreemapDataDetails are the nodes of the script treemap.
+type TreemapDataDetails struct {
+	Nodes []*TreemapNode `json:"nodes"`
+}
+
+// DebugDataDetails stores the debug data as is.
+type DebugDataDetails struct {
+	Data map[string]json.RawMessage
+}
+
+// UnknownDetails stores the details with an unknown type, or the details that cannot be unmarshaled as their type, as is.
+type UnknownDetails struct {
+	Type string
+	Data json.RawMessage
+}
+
+func (d *TableDetails) DetailsType() string                { return DetailsTypeTable }
+func (d *OpportunityDetails) DetailsType() string          { return DetailsTypeOpportunity }
+func (d *ListDetails) DetailsType() string                 { return DetailsTypeList }
+func (d *CriticalRequestChainDetails) DetailsType() string { return DetailsTypeCriticalRequestChain }
+func (d *ScreenshotDetails) DetailsType() string           { return DetailsTypeScreenshot }
+func (d *FilmstripDetails) DetailsType() string            { return DetailsTypeFilmstrip }
+func (d *TreemapDataDetails) DetailsType() string          { return DetailsTypeTreemapData }
+func (d *DebugDataDetails) DetailsType() string            { return DetailsTypeDebugData }
+func (d *UnknownDetails) DetailsType() string              { return d.Type }
+
+// The MarshalJSON methods write the details with the type field, in the same structure as the LHR.
+
+func (d *TableDetails) MarshalJSON() ([]byte, error) {
+	type details TableDetails
+	return marshalDetails(DetailsTypeTable, (*details)(d))
+}
+
+func (d *OpportunityDetails) MarshalJSON() ([]byte, error) {
+	type details OpportunityDetails
+	return marshalDetails(DetailsTypeOpportunity, (*details)(d))
+}
+
+func (d *ListDetails) MarshalJSON() ([]byte, error) {
+	type details ListDetails
+	return marshalDetails(DetailsTypeList, (*details)(d))
+}
+
+func (d *CriticalRequestChainDetails) MarshalJSON() ([]byte, error) {
+	type details CriticalRequestChainDetails
+	return marshalDetails(DetailsTypeCriticalRequestChain, (*details)(d))
+}
+
+func (d *ScreenshotDetails) MarshalJSON() ([]byte, error) {
+	type details ScreenshotDetails
+	return marshalDetails(DetailsTypeScreenshot, (*details)(d))
+}
+
+func (d *FilmstripDetails) MarshalJSON() ([]byte, error) {
+	type details FilmstripDetails
+	return marshalDetails(DetailsTypeFilmstrip, (*details)(d))
+}
+
+func (d *TreemapDataDetails) MarshalJSON() ([]byte, error) {
+	type details TreemapDataDetails
+	return marshalDetails(DetailsTypeTreemapData, (*details)(d))
+}
+
+func (d *DebugDataDetails) MarshalJSON() ([]byte, error) {
+
+	if d.Data == nil {
+		return marshalDetails(DetailsTypeDebugData, struct{}{})
+	}
+
+	return marshalDetails(DetailsTypeDebugData, d.Data)
+}
+
+func (d *UnknownDetails) MarshalJSON() ([]byte, error) {
+
+	if len(d.Data) == 0 {
+		return []byte("null"), nil
+	}
+
+	return d.Data, nil
+}
+
+// marshalDetails marshals v as an object and prepends the type field.
+func marshalDetails(t string, v any) ([]byte, error) {
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("invalid %s details: not an object", t)
+	}
+
+	typ, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(data)+len(typ)+9)
+	out = append(out, `{"type":`...)
+	out = append(out, typ...)
+
+	if len(data) > 2 {
+		out = append(out, ',')
+	}
+
+	return append(out, data[1:]...), nil
+}
+
+func (d *ListDetails) UnmarshalJSON(data []byte) error {
+
+	v := struct {
+		Items []json.RawMessage `json:"items"`
+	}{}
+
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+
+	d.Items = make([]AuditDetails, 0, len(v.Items))
+
+	for i := range v.Items {
+
+		d.Items = append(d.Items, unmarshalAuditDetails(v.Items[i]))
+	}
+
+	return nil
+}
+
+func (d *DebugDataDetails) UnmarshalJSON(data []byte) error {
+
+	err := json.Unmarshal(data, &d.Data)
+	if err != nil {
+		return err
+	}
+
+	delete(d.Data, "type")
+
+	return nil
+}
+
+// unmarshalAuditDetails unmarshals the details based on its type.
+//
+// If the type is unknown or the details cannot be unmarshaled as its type (eg.: the format changed in a new Lighthouse version),
+// returns the details as *UnknownDetails, so a single audit does not fail the whole LighthouseResult.
+func unmarshalAuditDetails(data []byte) AuditDetails {
+
+	t := struct {
+		Type string `json:"type"`
+	}{}
+
+	// The type is empty if data is not an object
+	_ = json.Unmarshal(data, &t)
+
+	var d AuditDetails
+
+	switch t.Type {
+	case DetailsTypeTable:
+		d = new(TableDetails)
+	case DetailsTypeOpportunity:
+		d = new(OpportunityDetails)
+	case DetailsTypeList:
+		d = new(ListDetails)
+	case DetailsTypeCriticalRequestChain:
+		d = new(CriticalRequestChainDetails)
+	case DetailsTypeScreenshot:
+		d = new(ScreenshotDetails)
+	case DetailsTypeFilmstrip:
+		d = new(FilmstripDetails)
+	case DetailsTypeTreemapData:
+		d = new(TreemapDataDetails)
+	case DetailsTypeDebugData:
+		d = new(DebugDataDetails)
+	default:
+		return &UnknownDetails{Type: t.Type, Data: append(json.RawMessage(nil), data...)}
+	}
+
+	if err := json.Unmarshal(data, d); err != nil {
+		return &UnknownDetails{Type: t.Type, Data: append(json.RawMessage(nil), data...)}
+	}
+
+	return d
+}
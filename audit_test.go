@@ -0,0 +1,140 @@
+package google_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+// readTestResult decodes the LighthouseResult from testdata/pagespeed.json.
+func readTestResult(t *testing.T, screenshots bool) *google.LighthouseResult {
+
+	f, err := os.Open("testdata/pagespeed.json")
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+	defer f.Close()
+
+	dec := google.NewResponseDecoder(f)
+	if screenshots {
+		dec.KeepScreenshots()
+	}
+
+	res, err := dec.DecodeLighthouseResult()
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	return res
+}
+
+func TestAuditFields(t *testing.T) {
+
+	res := readTestResult(t, false)
+
+	a := res.Audit("largest-contentful-paint")
+	if a == nil {
+		t.Fatalf("FAIL: audit not found\n")
+	}
+
+	if a.NumericValue != 2612.3 || a.NumericUnit != "millisecond" || a.DisplayValue != "2.6 s" {
+		t.Fatalf("FAIL: invalid audit: %#v\n", a)
+	}
+
+	if a := res.Audit("uses-http2"); a.ErrorMessage != "Required devtoolsLogs gatherer did not run." {
+		t.Fatalf("FAIL: invalid ErrorMessage: %s\n", a.ErrorMessage)
+	}
+
+	if a := res.Audit("inspector-issues"); len(a.Warnings) != 1 || a.Explanation != "Issues were found." {
+		t.Fatalf("FAIL: invalid Warnings/Explanation: %#v\n", a)
+	}
+}
+
+func TestAuditDetails(t *testing.T) {
+
+	res := readTestResult(t, false)
+
+	tests := map[string]string{
+		"mainthread-work-breakdown": google.DetailsTypeTable,
+		"render-blocking-resources": google.DetailsTypeOpportunity,
+		"critical-request-chains":   google.DetailsTypeCriticalRequestChain,
+		"final-screenshot":          google.DetailsTypeScreenshot,
+		"screenshot-thumbnails":     google.DetailsTypeFilmstrip,
+		"script-treemap-data":       google.DetailsTypeTreemapData,
+		"network-rtt":               google.DetailsTypeDebugData,
+	}
+
+	for id, typ := range tests {
+		if d := res.Audit(id).Details; d == nil || d.DetailsType() != typ {
+			t.Errorf("FAIL: invalid details of %s: %#v\n", id, d)
+		}
+	}
+
+	if res.Audit("video-caption").Details != nil {
+		t.Fatalf("FAIL: details of video-caption is not nil\n")
+	}
+
+	o := res.Audit("unused-javascript").Details.(*google.OpportunityDetails)
+
+	if o.OverallSavingsBytes != 122880 || len(o.Items) != 1 || o.Items[0].String("url") != "https://example.com/vendor.js" {
+		t.Fatalf("FAIL: invalid opportunity: %#v\n", o)
+	}
+
+	if sub := o.Items[0].SubItems(); len(sub) != 1 || sub[0].Float("sourceWastedBytes") != 71680 {
+		t.Fatalf("FAIL: invalid subItems: %#v\n", sub)
+	}
+
+	n := res.Audit("color-contrast").Details.(*google.TableDetails).Items[0].Node("node")
+	if n == nil || n.Selector != "body > p.note" || n.BoundingRect == nil || n.BoundingRect.Width != 200 {
+		t.Fatalf("FAIL: invalid node: %#v\n", n)
+	}
+
+	c := res.Audit("critical-request-chains").Details.(*google.CriticalRequestChainDetails)
+	if c.LongestChain.Length != 2 || c.Chains["A1"].Children["B2"].Request.URL != "https://example.com/style.css" {
+		t.Fatalf("FAIL: invalid chains: %#v\n", c)
+	}
+
+	// Screenshots are dropped by default
+	if d := res.Audit("final-screenshot").Details.(*google.ScreenshotDetails); d.Data != "" || d.Timing != 2901 {
+		t.Fatalf("FAIL: invalid screenshot: %#v\n", d)
+	}
+
+	res = readTestResult(t, true)
+
+	if d := res.Audit("screenshot-thumbnails").Details.(*google.FilmstripDetails); len(d.Items) != 2 || d.Items[1].Data != "data:image/jpeg;base64,CCCC" {
+		t.Fatalf("FAIL: invalid filmstrip: %#v\n", d)
+	}
+}
+
+func TestAuditDetailsInvalid(t *testing.T) {
+
+	report := `{"lighthouseVersion":"12.0.0","audits":{` +
+		`"bad-table":{"id":"bad-table","score":1,"details":{"type":"table","headings":"invalid","items":[]}},` +
+		`"bad-list":{"id":"bad-list","score":1,"details":{"type":"list","items":[{"type":"table","items":{}}]}},` +
+		`"good-table":{"id":"good-table","score":1,"details":{"type":"table","headings":[],"items":[]}}}}`
+
+	res, err := google.LoadLighthouseResult(strings.NewReader(report))
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	d, ok := res.Audit("bad-table").Details.(*google.UnknownDetails)
+	if !ok || d.Type != google.DetailsTypeTable || !strings.Contains(string(d.Data), `"headings":"invalid"`) {
+		t.Fatalf("FAIL: invalid details are not UnknownDetails: %#v\n", res.Audit("bad-table").Details)
+	}
+
+	l, ok := res.Audit("bad-list").Details.(*google.ListDetails)
+	if !ok || len(l.Items) != 1 || l.Items[0].DetailsType() != google.DetailsTypeTable {
+		t.Fatalf("FAIL: invalid list details: %#v\n", res.Audit("bad-list").Details)
+	}
+
+	if _, ok = l.Items[0].(*google.UnknownDetails); !ok {
+		t.Fatalf("FAIL: invalid list item is not UnknownDetails: %#v\n", l.Items[0])
+	}
+
+	if _, ok = res.Audit("good-table").Details.(*google.TableDetails); !ok {
+		t.Fatalf("FAIL: valid details are not TableDetails: %#v\n", res.Audit("good-table").Details)
+	}
+}
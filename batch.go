@@ -0,0 +1,237 @@
+package google
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// Batch endpoints
+const (
+	BatchEndpointPagespeed = "https://www.googleapis.com/batch/pagespeedonline/v5"
+)
+
+// Batch bundles multiple API calls into one multipart/mixed batch request.
+//
+// See: https://developers.google.com/webmaster-tools/v1/how-tos/batch
+type Batch struct {
+	c        *Client
+	endpoint string
+	reqs     []*http.Request
+}
+
+// BatchResponse stores the response of a single API call in the batch.
+//
+// The body of Response is read into memory, so closing it is not required.
+type BatchResponse struct {
+	Request  *http.Request
+	Response *http.Response
+
+	c    *Client
+	data []byte
+}
+
+// NewBatch returns an empty Batch that sends the calls to the batch endpoint (eg.: [BatchEndpointPagespeed]).
+func (c *Client) NewBatch(endpoint string) *Batch {
+	return &Batch{c: c, endpoint: endpoint}
+}
+
+// Add adds req to the batch and returns the index of its response.
+func (b *Batch) Add(req *http.Request) int {
+
+	b.reqs = append(b.reqs, req)
+
+	return len(b.reqs) - 1
+}
+
+// AddLighthouse adds a PageSpeed API call to the batch and returns the index of its response.
+//
+// If any error returned, that comes from [NewLighthouseRequest].
+func (b *Batch) AddLighthouse(u string, cred Credential, params ...LighthouseParam) (int, error) {
+
+	req, err := NewLighthouseRequest(context.Background(), u, cred, params...)
+	if err != nil {
+		return -1, err
+	}
+
+	return b.Add(req), nil
+}
+
+// Len returns the number of API calls in the batch.
+func (b *Batch) Len() int {
+	return len(b.reqs)
+}
+
+// Do sends the batch request and returns the responses in the order of the API calls.
+//
+// The returned error is not nil if the batch request itself failed.
+// The errors of the individual API calls are returned by [BatchResponse.Err].
+// The size of every response in the batch is limited by the Client (see [Client.SetMaxResponseSize]).
+func (b *Batch) Do(ctx context.Context) ([]*BatchResponse, error) {
+
+	body := new(bytes.Buffer)
+	mw := multipart.NewWriter(body)
+
+	for i := range b.reqs {
+
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Type", "application/http")
+		h.Set("Content-ID", "<item-"+strconv.Itoa(i)+">")
+
+		pw, err := mw.CreatePart(h)
+		if err != nil {
+			return nil, fmt.Errorf("create part %d: %w", i, err)
+		}
+
+		if err = b.reqs[i].Write(pw); err != nil {
+			return nil, fmt.Errorf("write request %d: %w", i, err)
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("close multipart: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+
+	resp, err := b.c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+
+		gerr, err := b.c.NewDecoder(resp.Body).DecodeErrorResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, gerr
+	}
+
+	return b.parse(resp)
+}
+
+// parse reads the parts of the multipart/mixed batch response.
+func (b *Batch) parse(resp *http.Response) ([]*BatchResponse, error) {
+
+	mt, mp, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Type: %w", err)
+	}
+
+	if mt != "multipart/mixed" {
+		return nil, fmt.Errorf("invalid Content-Type: %s", mt)
+	}
+
+	v := make([]*BatchResponse, len(b.reqs))
+
+	mr := multipart.NewReader(resp.Body, mp["boundary"])
+
+	for n := 0; ; n++ {
+
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read part: %w", err)
+		}
+
+		// The Content-ID of the response is "<response-item-N>"
+		i := n
+		if id := strings.Trim(part.Header.Get("Content-ID"), "<>"); id != "" {
+			if j := strings.LastIndex(id, "-"); j >= 0 {
+				if k, err := strconv.Atoi(id[j+1:]); err == nil {
+					i = k
+				}
+			}
+		}
+
+		if i < 0 || i >= len(v) {
+			return nil, fmt.Errorf("invalid part index: %d", i)
+		}
+
+		if v[i] != nil {
+			return nil, fmt.Errorf("duplicate response %d", i)
+		}
+
+		// The size limit of the Client applies to every part, not to the whole batch
+		r, err := http.ReadResponse(bufio.NewReader(b.c.NewDecoder(part).reader()), b.reqs[i])
+		if err != nil {
+			return nil, fmt.Errorf("read response %d: %w", i, err)
+		}
+
+		data, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read response %d: %w", i, err)
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(data))
+
+		v[i] = &BatchResponse{Request: b.reqs[i], Response: r, c: b.c, data: data}
+	}
+
+	for i := range v {
+		if v[i] == nil {
+			return nil, fmt.Errorf("missing response %d", i)
+		}
+	}
+
+	return v, nil
+}
+
+// Err returns the *Error if the API call failed.
+//
+// Returns nil, if the status code is 200.
+func (r *BatchResponse) Err() error {
+
+	if r.Response.StatusCode == 200 {
+		return nil
+	}
+
+	resp := r.response()
+
+	gerr, err := r.c.NewDecoder(resp.Body).DecodeErrorResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	return gerr
+}
+
+// LighthouseResult decodes the LighthouseResult from the response.
+//
+// If the API call failed, the error is returned by [BatchResponse.Err].
+func (r *BatchResponse) LighthouseResult() (*LighthouseResult, error) {
+
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.c.NewDecoder(r.response().Body).DecodeLighthouseResult()
+}
+
+// response returns a copy of Response with an unread body.
+func (r *BatchResponse) response() *http.Response {
+
+	v := *r.Response
+	v.Body = io.NopCloser(bytes.NewReader(r.data))
+
+	return &v
+}
@@ -0,0 +1,189 @@
+package google_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+// testBatchHandler responds to the API calls in reverse order, the call without key fails.
+func testBatchHandler(w http.ResponseWriter, r *http.Request) {
+
+	_, mp, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	mr := multipart.NewReader(r.Body, mp["boundary"])
+
+	type batchPart struct{ id, resp string }
+
+	var parts []batchPart
+
+	for i := 0; ; i++ {
+
+		part, err := mr.NextPart()
+		if err != nil {
+			break
+		}
+
+		req, err := http.ReadRequest(bufio.NewReader(part))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var resp string
+
+		if req.URL.Query().Get("key") == "" {
+			resp = "HTTP/1.1 400 Bad Request\r\nContent-Type: application/json\r\n\r\n" +
+				`{"error":{"code":400,"message":"API key not valid. Please pass a valid API key.","errors":[{"message":"API key not valid. Please pass a valid API key.","domain":"global","reason":"badRequest"}]}}`
+		} else {
+			resp = "HTTP/1.1 200 OK\r\nContent-Type: application/json\r\n\r\n" +
+				`{"lighthouseResult":{"requestedUrl":"` + req.URL.Query().Get("url") + `","categories":{"seo":{"id":"seo","score":1}}}}`
+		}
+
+		parts = append([]batchPart{{id: fmt.Sprintf("<response-item-%d>", i), resp: resp}}, parts...)
+	}
+
+	mw := multipart.NewWriter(w)
+
+	w.Header().Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+
+	for i := range parts {
+
+		pw, _ := mw.CreatePart(map[string][]string{"Content-Type": {"application/http"}, "Content-ID": {parts[i].id}})
+		pw.Write([]byte(parts[i].resp))
+	}
+
+	mw.Close()
+}
+
+func TestBatch(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(testBatchHandler))
+	defer srv.Close()
+
+	b := google.NewClient(srv.Client()).NewBatch(srv.URL)
+
+	if _, err := b.AddLighthouse("https://example.com/", google.NewApiKey("key"), google.LighthouseCategorySEO); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if _, err := b.AddLighthouse("https://example.org/", nil, google.LighthouseCategorySEO); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	resps, err := b.Do(context.TODO())
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if len(resps) != b.Len() {
+		t.Fatalf("FAIL: invalid number of responses: %d\n", len(resps))
+	}
+
+	res, err := resps[0].LighthouseResult()
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if res.RequestedURL().String() != "https://example.com/" {
+		t.Fatalf("FAIL: invalid RequestedURL: %s\n", res.RequestedURL())
+	}
+
+	_, err = resps[1].LighthouseResult()
+	if !errors.Is(err, google.ErrLighthouseInvalidKey) {
+		t.Fatalf("FAIL: error is not ErrLighthouseInvalidKey: %v\n", err)
+	}
+
+	var gerr *google.Error
+	if !errors.As(resps[1].Err(), &gerr) || gerr.Code != 400 {
+		t.Fatalf("FAIL: invalid *Error: %v\n", resps[1].Err())
+	}
+}
+
+// testBatchParts returns a handler that responds with the given Content-IDs, every part is a successful response.
+func testBatchParts(ids ...string) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		mw := multipart.NewWriter(w)
+
+		w.Header().Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+
+		for _, id := range ids {
+
+			pw, _ := mw.CreatePart(map[string][]string{"Content-Type": {"application/http"}, "Content-ID": {id}})
+			pw.Write([]byte("HTTP/1.1 200 OK\r\nContent-Type: application/json\r\n\r\n" +
+				`{"lighthouseResult":{"requestedUrl":"https://example.com/","categories":{"seo":{"id":"seo","score":1}}}}`))
+		}
+
+		mw.Close()
+	}
+}
+
+func TestBatchContentID(t *testing.T) {
+
+	cases := []struct {
+		name string
+		ids  []string
+		ok   bool
+	}{
+		{"reordered", []string{"<response-item-1>", "<response-item-0>"}, true},
+		{"duplicate", []string{"<response-item-0>", "<response-item-0>"}, false},
+		{"out of range", []string{"<response-item-0>", "<response-item-2>"}, false},
+		{"missing", []string{"<response-item-1>"}, false},
+	}
+
+	for _, tc := range cases {
+
+		srv := httptest.NewServer(testBatchParts(tc.ids...))
+
+		b := google.NewClient(srv.Client()).NewBatch(srv.URL)
+		b.AddLighthouse("https://example.com/", nil)
+		b.AddLighthouse("https://example.org/", nil)
+
+		_, err := b.Do(context.TODO())
+
+		srv.Close()
+
+		if (err == nil) != tc.ok {
+			t.Fatalf("FAIL: %s: unexpected error: %v\n", tc.name, err)
+		}
+	}
+}
+
+func TestBatchMaxResponseSize(t *testing.T) {
+
+	srv := httptest.NewServer(testBatchParts("<response-item-0>", "<response-item-1>"))
+	defer srv.Close()
+
+	c := google.NewClient(srv.Client())
+
+	b := c.NewBatch(srv.URL)
+	b.AddLighthouse("https://example.com/", nil)
+	b.AddLighthouse("https://example.org/", nil)
+
+	// The limit is per part, the whole body is larger than 256 bytes
+	c.SetMaxResponseSize(256)
+
+	if _, err := b.Do(context.TODO()); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	c.SetMaxResponseSize(64)
+
+	if _, err := b.Do(context.TODO()); !errors.Is(err, google.ErrResponseTooLarge) {
+		t.Fatalf("FAIL: error is not ErrResponseTooLarge: %v\n", err)
+	}
+}
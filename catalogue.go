@@ -0,0 +1,83 @@
+// Code generated by gencatalogue; DO NOT EDIT.
+
+package google
+
+// The known errors of the supported APIs, generated from the recorded responses in testdata/errors.
+var (
+
+	// Invalid domain
+	//
+	// Matches only the net::ERR_CONNECTION_FAILED details, use ErrLighthouseRuntimeFailedDocumentRequest to match the code only.
+	//
+	// Recorded in testdata/errors/lighthouse_failed_document_request.json
+	ErrLighthouseFailedDocumentRequest = &GoogleError{
+		Message: "Lighthouse returned error: FAILED_DOCUMENT_REQUEST. Lighthouse was unable to reliably load the page you requested. Make sure you are testing the correct URL and that the server is properly responding to all requests. (Details: net::ERR_CONNECTION_FAILED)",
+		Domain:  "lighthouse",
+		Reason:  "lighthouseUserError",
+	}
+
+	// Invalid category parameter
+	//
+	// Recorded in testdata/errors/lighthouse_invalid_category.json
+	ErrLighthouseInvalidCategory = &GoogleError{
+		Message: `^Invalid value at 'category' \(type\.googleapis\.com/google\.chrome\.pagespeedonline\.v5\.PagespeedonlinePagespeedapiRunpagespeedRequest\.Category\), .*$`,
+		Reason:  "invalid",
+	}
+
+	// Invalid API key
+	//
+	// Recorded in testdata/errors/lighthouse_invalid_key.json
+	ErrLighthouseInvalidKey = &GoogleError{
+		Message: "API key not valid. Please pass a valid API key.",
+		Domain:  "global",
+		Reason:  "badRequest",
+	}
+
+	// Invalid strategy parameter
+	//
+	// Recorded in testdata/errors/lighthouse_invalid_strategy.json
+	ErrLighthouseInvalidStrategy = &GoogleError{
+		Message: `^Invalid value at 'strategy' \(type\.googleapis\.com/google\.chrome\.pagespeedonline\.v5\.PagespeedonlinePagespeedapiRunpagespeedRequest\.Strategy\), .*$`,
+		Reason:  "invalid",
+	}
+
+	// Invalid url parameter
+	//
+	// Recorded in testdata/errors/lighthouse_invalid_url.json
+	ErrLighthouseInvalidUrl = &GoogleError{
+		Message:      `^Invalid value '.*'\. Values must match the following regular expression: '\(\?i\)\(url:\|origin:\)\?http\(s\)\?://\.\*'$`,
+		Domain:       "gdata.CoreErrorDomain",
+		Reason:       "INVALID_PARAMETER",
+		Location:     "url",
+		LocationType: "other",
+	}
+
+	// Rate limit
+	//
+	// Recorded in testdata/errors/lighthouse_rate_limit_exceeded.json
+	ErrLighthouseRateLimitExceeded = &GoogleError{
+		Message: `^Quota exceeded for quota metric 'Queries' and limit 'Queries per minute' of service 'pagespeedonline\.googleapis\.com' for consumer '.*'\.$`,
+		Domain:  "global",
+		Reason:  "rateLimitExceeded",
+	}
+
+	// Too much request
+	//
+	// Recorded in testdata/errors/lighthouse_unprocessable.json
+	ErrLighthouseUnprocessable = &GoogleError{
+		Message: "Unable to process request. Please wait a while and try again.",
+		Domain:  "global",
+		Reason:  "internalError",
+	}
+)
+
+// ErrorCatalogue maps the names of the known errors to the sentinels.
+var ErrorCatalogue = map[string]*GoogleError{
+	"ErrLighthouseFailedDocumentRequest": ErrLighthouseFailedDocumentRequest,
+	"ErrLighthouseInvalidCategory":       ErrLighthouseInvalidCategory,
+	"ErrLighthouseInvalidKey":            ErrLighthouseInvalidKey,
+	"ErrLighthouseInvalidStrategy":       ErrLighthouseInvalidStrategy,
+	"ErrLighthouseInvalidUrl":            ErrLighthouseInvalidUrl,
+	"ErrLighthouseRateLimitExceeded":     ErrLighthouseRateLimitExceeded,
+	"ErrLighthouseUnprocessable":         ErrLighthouseUnprocessable,
+}
@@ -0,0 +1,69 @@
+package google_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+// TestErrorCatalogue verifies that every sentinel in the catalogue matches its recorded response.
+func TestErrorCatalogue(t *testing.T) {
+
+	files, err := filepath.Glob("testdata/errors/*.json")
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if len(files) != len(google.ErrorCatalogue) {
+		t.Fatalf("FAIL: %d fixtures, %d errors in the catalogue, run go generate\n", len(files), len(google.ErrorCatalogue))
+	}
+
+	for i := range files {
+
+		data, err := os.ReadFile(files[i])
+		if err != nil {
+			t.Fatalf("FAIL: %s\n", err)
+		}
+
+		v := struct {
+			Name   string          `json:"name"`
+			Status int             `json:"status"`
+			Body   json.RawMessage `json:"body"`
+		}{}
+
+		if err = json.Unmarshal(data, &v); err != nil {
+			t.Fatalf("FAIL: %s: %s\n", files[i], err)
+		}
+
+		sentinel, ok := google.ErrorCatalogue[v.Name]
+		if !ok {
+			t.Errorf("FAIL: %s: %s is not in the catalogue, run go generate\n", files[i], v.Name)
+			continue
+		}
+
+		resp := &http.Response{StatusCode: v.Status, Body: io.NopCloser(bytes.NewReader(v.Body))}
+
+		gerr, err := google.ErrorFromResponse(resp)
+		if err != nil {
+			t.Fatalf("FAIL: %s: %s\n", files[i], err)
+		}
+
+		if !errors.Is(gerr, sentinel) {
+			t.Errorf("FAIL: %s: recorded error is not %s: %s\n", files[i], v.Name, gerr)
+		}
+
+		// The sentinel must match its own recorded response only
+		for name, other := range google.ErrorCatalogue {
+			if name != v.Name && errors.Is(gerr, other) {
+				t.Errorf("FAIL: %s: recorded error is %s\n", files[i], name)
+			}
+		}
+	}
+}
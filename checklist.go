@@ -0,0 +1,133 @@
+package google
+
+// ChecklistStatus is the status of an audit in a Checklist.
+type ChecklistStatus string
+
+// Possible values of ChecklistStatus
+const (
+	ChecklistPass          ChecklistStatus = "pass"
+	ChecklistFail          ChecklistStatus = "fail"
+	ChecklistNotApplicable ChecklistStatus = "not-applicable"
+	ChecklistManual        ChecklistStatus = "manual"      // The audit must be checked manually
+	ChecklistInformative   ChecklistStatus = "informative" // The audit is not scored
+	ChecklistError         ChecklistStatus = "error"       // The audit errored
+)
+
+// ChecklistItem is an audit in a Checklist.
+type ChecklistItem struct {
+	Audit      *Audit          `json:"audit"`
+	Group      string          `json:"group,omitempty"`      // The group of the audit (eg.: "seo-content"), empty if the audit is not grouped
+	GroupTitle string          `json:"groupTitle,omitempty"` // The title of the group
+	Status     ChecklistStatus `json:"status"`
+	Message    string          `json:"message,omitempty"`  // The explanation or the error message of the audit, or the display value (eg.: "1 link found")
+	Headings   []TableHeading  `json:"headings,omitempty"` // The headings of the items
+	Items      []DetailsItem   `json:"items,omitempty"`    // The items involved (eg.: the links without descriptive text, the console errors)
+}
+
+// Checklist is the audits of a category with their status.
+type Checklist struct {
+	Category CategoryID       `json:"category"`
+	Score    NullScore        `json:"score"`
+	Items    []*ChecklistItem `json:"items"` // In the order of the audits in the category
+}
+
+// Failed returns the failed items of the checklist.
+func (c *Checklist) Failed() []*ChecklistItem {
+
+	var v []*ChecklistItem
+
+	for i := range c.Items {
+		if c.Items[i].Status == ChecklistFail {
+			v = append(v, c.Items[i])
+		}
+	}
+
+	return v
+}
+
+// checklistStatus returns the status of the audit.
+func checklistStatus(a *Audit) ChecklistStatus {
+
+	switch a.ScoreDisplayMode {
+	case "notApplicable":
+		return ChecklistNotApplicable
+	case "manual":
+		return ChecklistManual
+	case "informative":
+		return ChecklistInformative
+	case "error":
+		return ChecklistError
+	}
+
+	s := a.Score
+
+	switch {
+	case !s.Valid:
+		return ChecklistInformative
+	case s.Value >= passThreshold:
+		return ChecklistPass
+	default:
+		return ChecklistFail
+	}
+}
+
+// Checklist returns the audits of the category with their status (eg.: for the SEO and the best-practices categories).
+//
+// If the category is missing, returns nil.
+func (r *LighthouseResult) Checklist(id CategoryID) *Checklist {
+
+	c := r.categories[string(id)]
+	if c == nil {
+		return nil
+	}
+
+	v := &Checklist{Category: id, Score: c.Score, Items: make([]*ChecklistItem, 0, len(c.AuditRefs))}
+
+	for _, ref := range c.AuditRefs {
+
+		a := r.audits[ref.ID]
+		if a == nil {
+			continue
+		}
+
+		i := &ChecklistItem{Audit: a, Group: ref.Group, Status: checklistStatus(a)}
+
+		if g := r.categoryGroups[ref.Group]; g != nil {
+			i.GroupTitle = g.Title
+		}
+
+		switch {
+		case a.Explanation != "":
+			i.Message = a.Explanation
+		case a.ErrorMessage != "":
+			i.Message = a.ErrorMessage
+		default:
+			i.Message = a.DisplayValue
+		}
+
+		switch d := a.Details.(type) {
+		case *TableDetails:
+			i.Headings, i.Items = d.Headings, d.Items
+		case *OpportunityDetails:
+			i.Headings, i.Items = d.Headings, d.Items
+		}
+
+		v.Items = append(v.Items, i)
+	}
+
+	return v
+}
+
+// SEOChecklist returns the checklist of the SEO category.
+//
+// If the category is missing, returns nil.
+func (r *LighthouseResult) SEOChecklist() *Checklist {
+	return r.Checklist(CategorySEO)
+}
+
+// BestPracticesChecklist returns the checklist of the best-practices category.
+//
+// If the category is missing, returns nil.
+func (r *LighthouseResult) BestPracticesChecklist() *Checklist {
+	return r.Checklist(CategoryBestPractices)
+}
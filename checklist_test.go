@@ -0,0 +1,113 @@
+package google_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+func TestSEOChecklist(t *testing.T) {
+
+	v := readTestResult(t, false).SEOChecklist()
+
+	if v == nil || v.Category != google.CategorySEO || len(v.Items) != 3 {
+		t.Fatalf("FAIL: invalid checklist: %#v\n", v)
+	}
+
+	status := map[string]google.ChecklistStatus{"meta-description": google.ChecklistPass, "link-text": google.ChecklistFail, "structured-data": google.ChecklistManual}
+
+	for _, i := range v.Items {
+		if i.Status != status[i.Audit.ID] {
+			t.Fatalf("FAIL: invalid status of %s: %s\n", i.Audit.ID, i.Status)
+		}
+	}
+
+	f := v.Failed()
+	if len(f) != 1 || f[0].Audit.ID != "link-text" || f[0].Message != "1 link found" || f[0].GroupTitle != "Content Best Practices" {
+		t.Fatalf("FAIL: invalid failed items: %#v\n", f)
+	}
+
+	if len(f[0].Items) != 1 || f[0].Items[0].String("href") != "https://example.com/more" || f[0].Items[0].String("text") != "click here" {
+		t.Fatalf("FAIL: invalid items: %v\n", f[0].Items)
+	}
+}
+
+func TestChecklistItemMarshalJSON(t *testing.T) {
+
+	data, err := json.Marshal(readTestResult(t, false).SEOChecklist().Failed()[0])
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	v := struct {
+		Audit struct {
+			ID string `json:"id"`
+		} `json:"audit"`
+		Group      string                 `json:"group"`
+		GroupTitle string                 `json:"groupTitle"`
+		Status     google.ChecklistStatus `json:"status"`
+		Message    string                 `json:"message"`
+		Headings   []google.TableHeading  `json:"headings"`
+		Items      []map[string]any       `json:"items"`
+	}{}
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if v.Audit.ID != "link-text" || v.Status != google.ChecklistFail || v.Message != "1 link found" || v.GroupTitle != "Content Best Practices" || v.Group == "" {
+		t.Fatalf("FAIL: invalid JSON: %s\n", data)
+	}
+
+	if len(v.Headings) == 0 || len(v.Items) != 1 || v.Items[0]["href"] != "https://example.com/more" {
+		t.Fatalf("FAIL: invalid items in JSON: %s\n", data)
+	}
+}
+
+func TestBestPracticesChecklist(t *testing.T) {
+
+	v := readTestResult(t, false).BestPracticesChecklist()
+
+	status := map[string]google.ChecklistStatus{
+		"is-on-https":       google.ChecklistPass,
+		"errors-in-console": google.ChecklistFail,
+		"deprecations":      google.ChecklistFail,
+		"inspector-issues":  google.ChecklistPass,
+		"uses-http2":        google.ChecklistError,
+	}
+
+	if v == nil || len(v.Items) != len(status) {
+		t.Fatalf("FAIL: invalid checklist: %#v\n", v)
+	}
+
+	for _, i := range v.Items {
+
+		if i.Status != status[i.Audit.ID] {
+			t.Fatalf("FAIL: invalid status of %s: %s\n", i.Audit.ID, i.Status)
+		}
+
+		switch i.Audit.ID {
+		case "errors-in-console":
+			if len(i.Items) != 1 || i.Items[0].SourceLocation("sourceLocation") == nil {
+				t.Fatalf("FAIL: invalid console errors: %v\n", i.Items)
+			}
+		case "deprecations":
+			if len(i.Items) != 1 || i.Items[0].String("value") == "" {
+				t.Fatalf("FAIL: invalid deprecations: %v\n", i.Items)
+			}
+		case "uses-http2":
+			if i.Message != "Required devtoolsLogs gatherer did not run." {
+				t.Fatalf("FAIL: invalid error message: %s\n", i.Message)
+			}
+		case "inspector-issues":
+			if i.Message != "Issues were found." {
+				t.Fatalf("FAIL: invalid explanation: %s\n", i.Message)
+			}
+		}
+	}
+
+	if readTestResult(t, false).Checklist("pwa") != nil {
+		t.Fatalf("FAIL: checklist of missing category\n")
+	}
+}
@@ -0,0 +1,265 @@
+package google
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"syscall"
+)
+
+// walkErrors calls fn for err and every error in the tree of err (see [errors.Unwrap]), until fn returns true.
+//
+// Returns true if fn returned true.
+func walkErrors(err error, fn func(error) bool) bool {
+
+	if err == nil {
+		return false
+	}
+
+	if fn(err) {
+		return true
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return walkErrors(e.Unwrap(), fn)
+	case interface{ Unwrap() []error }:
+		for _, v := range e.Unwrap() {
+			if walkErrors(v, fn) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// statusCode returns the HTTP status code of the first *Error in the tree of err.
+//
+// If no *Error found, returns 0.
+func statusCode(err error) int {
+
+	var e *Error
+
+	if !errors.As(err, &e) {
+		return 0
+	}
+
+	if e.HTTPStatus != 0 {
+		return e.HTTPStatus
+	}
+
+	return e.Code
+}
+
+// hasStatus returns whether any *Error in the tree of err has one of the canonical error codes.
+func hasStatus(err error, status ...string) bool {
+
+	return walkErrors(err, func(err error) bool {
+
+		e, ok := err.(*Error)
+		if !ok {
+			return false
+		}
+
+		for i := range status {
+			if e.Status == status[i] {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// hasReason returns whether any *GoogleError in the tree of err or the ErrorInfo of any *Error has one of the reasons.
+func hasReason(err error, reasons ...string) bool {
+
+	return walkErrors(err, func(err error) bool {
+
+		var reason string
+
+		switch e := err.(type) {
+		case *GoogleError:
+			reason = e.Reason
+		case *Error:
+			if i := e.ErrorInfo(); i != nil {
+				reason = i.Reason
+			}
+		}
+
+		for i := range reasons {
+			if reason == reasons[i] {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// runtimeCode returns the code of the first Lighthouse runtime error in the tree of err,
+// either a *LighthouseRuntimeError or a *GoogleError in the lighthouse domain.
+//
+// If no runtime error found, returns an empty string.
+func runtimeCode(err error) string {
+
+	var code string
+
+	walkErrors(err, func(err error) bool {
+
+		switch e := err.(type) {
+		case *LighthouseRuntimeError:
+			code = e.Code
+		case *GoogleError:
+			if e.Domain == "lighthouse" {
+				code = lighthouseCode(e.Message)
+			}
+		}
+
+		return code != ""
+	})
+
+	return code
+}
+
+// pageErrorCodes are the runtime error codes of the unreachable pages.
+var pageErrorCodes = []string{
+	"DNS_FAILURE",
+	"FAILED_DOCUMENT_REQUEST",
+	"ERRORED_DOCUMENT_REQUEST",
+	"NO_DOCUMENT_REQUEST",
+	"INSECURE_DOCUMENT_REQUEST",
+	"NOT_HTML",
+	"CHROME_INTERSTITIAL_ERROR",
+}
+
+// transientRuntimeCodes are the runtime error codes of the Lighthouse failures that are worth retrying.
+var transientRuntimeCodes = []string{
+	"PROTOCOL_TIMEOUT",
+	"REQUEST_CONTENT_TIMEOUT",
+	"PAGE_HUNG",
+	"TARGET_CRASHED",
+	"NO_TRACING_STARTED",
+	"TRACING_ALREADY_STARTED",
+	"READ_FAILED",
+	"PARSING_PROBLEM",
+	"MISSING_REQUIRED_ARTIFACT",
+	"ERRORED_REQUIRED_ARTIFACT",
+	"UNKNOWN_ERROR",
+}
+
+// IsPageError returns whether err is caused by an unreachable page (eg.: ErrLighthouseRuntimeDNSFailure, ErrLighthouseRuntimeFailedDocumentRequest).
+func IsPageError(err error) bool {
+	return slices.Contains(pageErrorCodes, runtimeCode(err))
+}
+
+// IsQuotaError returns whether err is caused by an exceeded quota or rate limit.
+func IsQuotaError(err error) bool {
+
+	if statusCode(err) == http.StatusTooManyRequests {
+		return true
+	}
+
+	return hasStatus(err, "RESOURCE_EXHAUSTED") ||
+		hasReason(err, "rateLimitExceeded", "userRateLimitExceeded", "dailyLimitExceeded", "quotaExceeded", "RATE_LIMIT_EXCEEDED")
+}
+
+// IsAuthError returns whether err is caused by an invalid credential or a missing permission.
+//
+// The invalid API key is reported by the API as 400 Bad Request (eg.: ErrLighthouseInvalidKey).
+func IsAuthError(err error) bool {
+
+	if errors.Is(err, ErrLighthouseInvalidKey) {
+		return true
+	}
+
+	if hasStatus(err, "UNAUTHENTICATED", "PERMISSION_DENIED") ||
+		hasReason(err, "keyInvalid", "keyExpired", "authError", "forbidden", "accessNotConfigured", "API_KEY_INVALID", "API_KEY_EXPIRED", "SERVICE_DISABLED") {
+		return true
+	}
+
+	switch statusCode(err) {
+	case http.StatusUnauthorized:
+		return true
+	case http.StatusForbidden:
+		// Some APIs report the exceeded quota with 403
+		return !IsQuotaError(err)
+	default:
+		return false
+	}
+}
+
+// IsInvalidArgument returns whether err is caused by an invalid request parameter (eg.: ErrLighthouseInvalidUrl).
+func IsInvalidArgument(err error) bool {
+
+	if IsAuthError(err) {
+		return false
+	}
+
+	if hasStatus(err, "INVALID_ARGUMENT", "FAILED_PRECONDITION", "OUT_OF_RANGE") ||
+		hasReason(err, "invalid", "invalidParameter", "INVALID_PARAMETER", "required", "badRequest") {
+		return true
+	}
+
+	return statusCode(err) == http.StatusBadRequest
+}
+
+// IsNotFound returns whether err is caused by a missing resource.
+func IsNotFound(err error) bool {
+
+	return statusCode(err) == http.StatusNotFound ||
+		hasStatus(err, "NOT_FOUND") ||
+		hasReason(err, "notFound")
+}
+
+// IsRetryable returns whether the request that caused err can be retried later.
+//
+// Errors caused by the tested page (eg.: ErrLighthouseFailedDocumentRequest, see [IsPageError]), by the request and
+// by the exhausted daily quota are not retryable.
+// Of the Lighthouse runtime errors, only the transient failures of Lighthouse are retryable (eg.: ErrLighthouseRuntimeProtocolTimeout,
+// ErrLighthouseRuntimePageHung, ErrLighthouseRuntimeTargetCrashed), the metric errors (eg.: ErrLighthouseRuntimeNoFCP) are not.
+// The cancelled context is not retryable, the deadline exceeded (eg.: [http.Client] timeout), the
+// network timeouts and the connections closed or reset by the server are retryable, so the caller must check
+// its own context before retrying.
+func IsRetryable(err error) bool {
+
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, context.Canceled):
+		return false
+	case errors.Is(err, context.DeadlineExceeded):
+		return true
+	case errors.Is(err, ErrResponseTooLarge):
+		return false
+	case runtimeCode(err) != "":
+		return slices.Contains(transientRuntimeCodes, runtimeCode(err))
+	case hasReason(err, "lighthouseUserError", "dailyLimitExceeded", "quotaExceeded"):
+		return false
+	case IsAuthError(err), IsInvalidArgument(err), IsNotFound(err):
+		return false
+	case errors.Is(err, ErrLighthouseUnprocessable), IsQuotaError(err):
+		return true
+	case hasStatus(err, "UNAVAILABLE", "DEADLINE_EXCEEDED", "INTERNAL", "ABORTED"),
+		hasReason(err, "backendError", "internalError"):
+		return true
+	}
+
+	switch statusCode(err) {
+	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	// The connection closed or reset by the server (eg.: a truncated body)
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var nerr net.Error
+
+	return errors.As(err, &nerr) && nerr.Timeout()
+}
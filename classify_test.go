@@ -0,0 +1,91 @@
+package google_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+func TestErrorClassification(t *testing.T) {
+
+	newErr := func(code int, status string, errs ...error) error {
+		return google.NewLighthouseError("https://example.com/", &google.Error{Code: code, HTTPStatus: code, Status: status, Errors: errs})
+	}
+
+	tests := []struct {
+		name                                      string
+		err                                       error
+		retryable, quota, auth, invalid, notFound bool
+	}{
+		{"nil", nil, false, false, false, false, false},
+		{"canceled", fmt.Errorf("get: %w", context.Canceled), false, false, false, false, false},
+		{"deadline", fmt.Errorf("get: %w", context.DeadlineExceeded), true, false, false, false, false},
+		{"rate limit", newErr(429, "RESOURCE_EXHAUSTED", google.NewGoogleError("global", "rateLimitExceeded", "Quota exceeded", "", "")), true, true, false, false, false},
+		{"daily limit", newErr(403, "", google.NewGoogleError("usageLimits", "dailyLimitExceeded", "Daily Limit Exceeded", "", "")), false, true, false, false, false},
+		{"unprocessable", newErr(500, "", google.NewGoogleError("global", "internalError", "Unable to process request. Please wait a while and try again.", "", "")), true, false, false, false, false},
+		{"invalid key", newErr(400, "INVALID_ARGUMENT", google.NewGoogleError("global", "badRequest", "API key not valid. Please pass a valid API key.", "", "")), false, false, true, false, false},
+		{"unauthenticated", newErr(401, "UNAUTHENTICATED"), false, false, true, false, false},
+		{"invalid url", newErr(400, "INVALID_ARGUMENT", google.NewGoogleError("gdata.CoreErrorDomain", "INVALID_PARAMETER", "Invalid value 'example.com'.", "other", "url")), false, false, false, true, false},
+		{"not found", newErr(404, "NOT_FOUND"), false, false, false, false, true},
+		{"unavailable", newErr(503, "UNAVAILABLE"), true, false, false, false, false},
+		{"failed document request", newErr(500, "", google.NewGoogleError("lighthouse", "lighthouseUserError", "Lighthouse returned error: FAILED_DOCUMENT_REQUEST.", "", "")), false, false, false, false, false},
+		{"runtime dns failure", google.NewLighthouseError("https://example.com/", google.NewLighthouseRuntimeError("DNS_FAILURE", "DNS servers could not resolve the provided domain.")), false, false, false, false, false},
+		{"runtime protocol timeout", google.NewLighthouseError("https://example.com/", google.NewLighthouseRuntimeError("PROTOCOL_TIMEOUT", "Waiting for DevTools protocol response has exceeded the allotted time.")), true, false, false, false, false},
+		{"runtime page hung", newErr(500, "", google.NewGoogleError("lighthouse", "lighthouseUserError", "Lighthouse returned error: PAGE_HUNG.", "", "")), true, false, false, false, false},
+		{"runtime request content timeout", google.NewLighthouseError("https://example.com/", google.NewLighthouseRuntimeError("REQUEST_CONTENT_TIMEOUT", "Fetching resource content has exceeded the allotted time.")), true, false, false, false, false},
+		{"runtime no fcp", google.NewLighthouseRuntimeError("NO_FCP", "The page did not paint any content."), false, false, false, false, false},
+		{"unexpected eof", google.NewLighthouseError("https://example.com/", io.ErrUnexpectedEOF), true, false, false, false, false},
+		{"connection reset", google.NewLighthouseError("https://example.com/", &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), true, false, false, false, false},
+		{"eof", fmt.Errorf("get: %w", io.EOF), true, false, false, false, false},
+		{"too large", fmt.Errorf("read error: %w", google.ErrResponseTooLarge), false, false, false, false, false},
+		{"unknown", errors.New("unknown"), false, false, false, false, false},
+	}
+
+	for _, tt := range tests {
+
+		if v := google.IsRetryable(tt.err); v != tt.retryable {
+			t.Errorf("%s: IsRetryable() = %v\n", tt.name, v)
+		}
+
+		if v := google.IsQuotaError(tt.err); v != tt.quota {
+			t.Errorf("%s: IsQuotaError() = %v\n", tt.name, v)
+		}
+
+		if v := google.IsAuthError(tt.err); v != tt.auth {
+			t.Errorf("%s: IsAuthError() = %v\n", tt.name, v)
+		}
+
+		if v := google.IsInvalidArgument(tt.err); v != tt.invalid {
+			t.Errorf("%s: IsInvalidArgument() = %v\n", tt.name, v)
+		}
+
+		if v := google.IsNotFound(tt.err); v != tt.notFound {
+			t.Errorf("%s: IsNotFound() = %v\n", tt.name, v)
+		}
+	}
+}
+
+func TestIsPageError(t *testing.T) {
+
+	cases := map[error]bool{
+		google.ErrLighthouseRuntimeDNSFailure:                                                                                      true,
+		fmt.Errorf("run: %w", google.ErrLighthouseRuntimeFailedDocumentRequest):                                                    true,
+		google.NewGoogleError("lighthouse", "lighthouseUserError", "Lighthouse returned error: ERRORED_DOCUMENT_REQUEST.", "", ""): true,
+		google.ErrLighthouseRuntimeProtocolTimeout:                                                                                 false,
+		google.ErrLighthouseRuntimeNoFCP:                                                                                           false,
+		errors.New("unknown"):                                                                                                      false,
+	}
+
+	for err, want := range cases {
+		if google.IsPageError(err) != want {
+			t.Fatalf("FAIL: IsPageError(%v) = %v\n", err, !want)
+		}
+	}
+}
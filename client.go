@@ -0,0 +1,64 @@
+package google
+
+import (
+	"io"
+	"net/http"
+)
+
+// Client sends the requests to the Google APIs.
+//
+// The responses are decoded with the options of the Client (see [Client.SetMaxResponseSize], [Client.KeepScreenshots] and [Client.KeepRaw]).
+type Client struct {
+	hc          *http.Client
+	maxSize     int64
+	screenshots bool
+	raw         bool
+}
+
+// DefaultClient is the Client used by the package level functions (eg.: [RunLighthouse]).
+var DefaultClient = NewClient(nil)
+
+// NewClient returns a Client that uses hc to send the requests.
+//
+// If hc is nil, [http.DefaultClient] is used.
+func NewClient(hc *http.Client) *Client {
+
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	return &Client{hc: hc, maxSize: DefaultMaxResponseSize}
+}
+
+// SetMaxResponseSize sets the maximum number of bytes read from a response body, [DefaultMaxResponseSize] by default.
+//
+// If n is zero or negative, the size is not limited.
+func (c *Client) SetMaxResponseSize(n int64) {
+	c.maxSize = n
+}
+
+// KeepScreenshots enables the decoding of the screenshots in the responses (see [ResponseDecoder.KeepScreenshots]).
+func (c *Client) KeepScreenshots() {
+	c.screenshots = true
+}
+
+// KeepRaw keeps the original JSON of the decoded results (see [ResponseDecoder.KeepRaw]).
+func (c *Client) KeepRaw() {
+	c.raw = true
+}
+
+// NewDecoder returns a ResponseDecoder that reads from r with the options of c.
+func (c *Client) NewDecoder(r io.Reader) *ResponseDecoder {
+
+	d := NewResponseDecoder(r)
+	d.SetMaxSize(c.maxSize)
+	d.screenshots = c.screenshots
+	d.raw = c.raw
+
+	return d
+}
+
+// Do sends the request and returns the response.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	return c.hc.Do(req)
+}
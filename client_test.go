@@ -0,0 +1,296 @@
+package google_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g0rbe/go-google"
+	"github.com/g0rbe/go-google/internal/fakeapi"
+)
+
+// newFakeClient starts a fake API server and returns a Client connected to it.
+func newFakeClient(t *testing.T) (*fakeapi.Server, *google.Client) {
+
+	srv := fakeapi.NewServer()
+	t.Cleanup(srv.Close)
+
+	return srv, google.NewClient(srv.HTTPClient())
+}
+
+func TestClientRunLighthouseFake(t *testing.T) {
+
+	_, c := newFakeClient(t)
+
+	res, err := c.RunLighthouse("https://example.com/", google.NewApiKey("test"))
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if res.Score("performance") != 99 {
+		t.Fatalf("FAIL: invalid performance score: %d\n", res.Score("performance"))
+	}
+}
+
+func TestClientRunLighthouseFaults(t *testing.T) {
+
+	cases := []struct {
+		name      string
+		resp      fakeapi.Response
+		status    int
+		retryable bool
+	}{
+		{"429", fakeapi.TooManyRequests(30 * time.Second), 429, true},
+		{"500", fakeapi.InternalError(), 500, true},
+		{"503", fakeapi.Unavailable(), 503, true},
+		{"502 HTML", fakeapi.NonJSON(http.StatusBadGateway), 502, true},
+		{"404 HTML", fakeapi.NonJSON(http.StatusNotFound), 404, false},
+		{"400", fakeapi.Error(400, "INVALID_ARGUMENT", "invalid", "Invalid value"), 400, false},
+	}
+
+	srv, c := newFakeClient(t)
+
+	for _, tc := range cases {
+
+		srv.Script(tc.resp)
+
+		_, err := c.RunLighthouse("https://example.com/", nil)
+
+		var gerr *google.Error
+		if !errors.As(err, &gerr) {
+			t.Fatalf("FAIL: %s: error is not *Error: %v\n", tc.name, err)
+		}
+
+		if gerr.Code != tc.status {
+			t.Fatalf("FAIL: %s: invalid code: %d\n", tc.name, gerr.Code)
+		}
+
+		if google.IsRetryable(err) != tc.retryable {
+			t.Fatalf("FAIL: %s: IsRetryable is %v\n", tc.name, !tc.retryable)
+		}
+	}
+}
+
+func TestClientRunLighthouseRetryAfter(t *testing.T) {
+
+	srv, c := newFakeClient(t)
+
+	srv.Script(fakeapi.TooManyRequests(30 * time.Second))
+
+	_, err := c.RunLighthouse("https://example.com/", nil)
+
+	var gerr *google.Error
+	if !errors.As(err, &gerr) || !google.IsQuotaError(err) {
+		t.Fatalf("FAIL: error is not a quota error: %v\n", err)
+	}
+
+	if gerr.RetryAfter() != 30*time.Second {
+		t.Fatalf("FAIL: invalid RetryAfter: %s\n", gerr.RetryAfter())
+	}
+}
+
+func TestClientRunLighthouseLatency(t *testing.T) {
+
+	srv := fakeapi.NewServer()
+	t.Cleanup(srv.Close)
+
+	hc := srv.HTTPClient()
+	hc.Timeout = 50 * time.Millisecond
+
+	srv.Script(fakeapi.Response{Latency: time.Second})
+
+	_, err := google.NewClient(hc).RunLighthouse("https://example.com/", nil)
+	if err == nil {
+		t.Fatalf("FAIL: no error after timeout\n")
+	}
+
+	if !google.IsRetryable(err) {
+		t.Fatalf("FAIL: timeout is not retryable: %v\n", err)
+	}
+}
+
+func TestClientRunLighthouseTruncated(t *testing.T) {
+
+	srv, c := newFakeClient(t)
+
+	srv.Script(fakeapi.Truncated(100))
+
+	_, err := c.RunLighthouse("https://example.com/", nil)
+	if err == nil {
+		t.Fatalf("FAIL: no error for truncated body\n")
+	}
+
+	if !google.IsRetryable(err) {
+		t.Fatalf("FAIL: truncated body is not retryable: %v\n", err)
+	}
+}
+
+func TestClientRunLighthouseRuntimeError(t *testing.T) {
+
+	srv, c := newFakeClient(t)
+
+	srv.Script(fakeapi.RuntimeError("NO_FCP", "The page did not paint any content."))
+
+	_, err := c.RunLighthouse("https://example.com/", nil)
+	if !errors.Is(err, google.ErrLighthouseRuntimeNoFCP) {
+		t.Fatalf("FAIL: error is not ErrLighthouseRuntimeNoFCP: %v\n", err)
+	}
+
+	var rerr *google.LighthouseRuntimeError
+	if !errors.As(err, &rerr) || google.IsRetryable(err) {
+		t.Fatalf("FAIL: invalid runtime error: %v\n", err)
+	}
+
+	srv.Script(fakeapi.RuntimeError("PROTOCOL_TIMEOUT", "Waiting for DevTools protocol response has exceeded the allotted time."),
+		fakeapi.RuntimeError("DNS_FAILURE", "DNS servers could not resolve the provided domain."))
+
+	if _, err = c.RunLighthouse("https://example.com/", nil); !google.IsRetryable(err) || google.IsPageError(err) {
+		t.Fatalf("FAIL: PROTOCOL_TIMEOUT is not a retryable error: %v\n", err)
+	}
+
+	if _, err = c.RunLighthouse("https://example.com/", nil); google.IsRetryable(err) || !google.IsPageError(err) {
+		t.Fatalf("FAIL: DNS_FAILURE is not a page error: %v\n", err)
+	}
+}
+
+func TestClientRunLighthouseQuota(t *testing.T) {
+
+	srv, c := newFakeClient(t)
+
+	srv.SetQuota(2)
+
+	key := google.NewApiKey("key-a")
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.RunLighthouse("https://example.com/", key); err != nil {
+			t.Fatalf("FAIL: call %d: %s\n", i, err)
+		}
+	}
+
+	_, err := c.RunLighthouse("https://example.com/", key)
+	if !google.IsQuotaError(err) || !google.IsRetryable(err) {
+		t.Fatalf("FAIL: error is not a retryable quota error: %v\n", err)
+	}
+
+	// The quota is per key
+	if _, err = c.RunLighthouse("https://example.com/", google.NewApiKey("key-b")); err != nil {
+		t.Fatalf("FAIL: other key: %s\n", err)
+	}
+
+	if srv.Calls("key-a") != 3 || srv.Calls("key-b") != 1 {
+		t.Fatalf("FAIL: invalid number of calls: %d, %d\n", srv.Calls("key-a"), srv.Calls("key-b"))
+	}
+}
+
+func TestErrorFromResponseFake(t *testing.T) {
+
+	srv := fakeapi.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.Script(fakeapi.Unavailable(), fakeapi.NonJSON(http.StatusServiceUnavailable))
+
+	for i := 0; i < 2; i++ {
+
+		resp, err := srv.HTTPClient().Get("https://www.googleapis.com/pagespeedonline/v5/runPagespeed?key=secret")
+		if err != nil {
+			t.Fatalf("FAIL: %s\n", err)
+		}
+
+		gerr, err := google.ErrorFromResponse(resp)
+		resp.Body.Close()
+
+		if err != nil {
+			t.Fatalf("FAIL: %d: %s\n", i, err)
+		}
+
+		if gerr.HTTPStatus != 503 || !google.IsRetryable(gerr) {
+			t.Fatalf("FAIL: %d: invalid error: %#v\n", i, gerr)
+		}
+	}
+}
+
+func TestClientOptions(t *testing.T) {
+
+	srv, c := newFakeClient(t)
+
+	c.SetMaxResponseSize(64)
+
+	_, err := c.RunLighthouse("https://example.com/", nil)
+	if !errors.Is(err, google.ErrResponseTooLarge) {
+		t.Fatalf("FAIL: error is not ErrResponseTooLarge: %v\n", err)
+	}
+
+	// The limit applies to the error responses too
+	srv.Script(fakeapi.InternalError())
+
+	_, err = c.RunLighthouse("https://example.com/", nil)
+	if !errors.Is(err, google.ErrResponseTooLarge) {
+		t.Fatalf("FAIL: error is not ErrResponseTooLarge: %v\n", err)
+	}
+
+	c.SetMaxResponseSize(0)
+	c.KeepScreenshots()
+
+	srv.Script(fakeapi.Response{Body: `{"lighthouseResult":{"requestedUrl":"https://example.com/","finalUrl":"https://example.com/",` +
+		`"fullPageScreenshot":{"screenshot":{"data":"data:image/webp;base64,AAAA","width":412,"height":823}}}}`})
+
+	res, err := c.RunLighthouse("https://example.com/", nil)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if s := res.FullPageScreenshot(); s == nil || s.Width != 412 {
+		t.Fatalf("FAIL: invalid screenshot: %#v\n", s)
+	}
+
+	c.KeepRaw()
+
+	raw := `{"requestedUrl":"https://example.com/","finalUrl":"https://example.com/","lighthouseVersion":"12.0.0"}`
+
+	srv.Script(fakeapi.Response{Body: `{"lighthouseResult":` + raw + `}`})
+
+	res, err = c.RunLighthouse("https://example.com/", nil)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	// The original JSON is kept with the fields not decoded by LighthouseResult
+	if data, err := json.Marshal(res); err != nil || string(data) != raw {
+		t.Fatalf("FAIL: invalid raw JSON: %s, %v\n", data, err)
+	}
+}
+
+func TestClientLighthouseErrorRedactsParams(t *testing.T) {
+
+	srv, c := newFakeClient(t)
+
+	srv.Script(fakeapi.InternalError())
+
+	_, err := c.RunLighthouse("https://example.com/", nil, google.LighthouseCaptchaToken("captcha-secret-token"), google.QuotaUser("user@example.com"), google.LighthouseStrategyMobile)
+
+	var lerr *google.LighthouseError
+	if !errors.As(err, &lerr) {
+		t.Fatalf("FAIL: error is not *LighthouseError: %v\n", err)
+	}
+
+	// The request is sent with the original values
+	if q := srv.Queries()[0]; q.Get("captchaToken") != "captcha-secret-token" || q.Get("quotaUser") != "user@example.com" {
+		t.Fatalf("FAIL: invalid query: %v\n", q)
+	}
+
+	if lerr.Params[0].Value() != "...oken" || lerr.Params[1].Value() != "....com" || lerr.Params[2].Value() != "mobile" {
+		t.Fatalf("FAIL: params are not redacted: %v\n", lerr.Params)
+	}
+
+	data, err := json.Marshal(lerr)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if strings.Contains(string(data), "captcha-secret-token") || strings.Contains(string(data), "user@example.com") {
+		t.Fatalf("FAIL: sensitive params in JSON: %s\n", data)
+	}
+}
@@ -54,3 +54,19 @@ func (k *ApiKey) Token() (string, error) {
 
 	return k.keys[k.next], nil
 }
+
+// redactToken returns the last 4 characters of token prefixed with "...".
+//
+// Returns an empty string, if token is empty.
+// Returns "REDACTED", if token is too short to show any part of it.
+func redactToken(token string) string {
+
+	switch {
+	case token == "":
+		return ""
+	case len(token) < 12:
+		return "REDACTED"
+	default:
+		return "..." + token[len(token)-4:]
+	}
+}
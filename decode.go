@@ -0,0 +1,545 @@
+package google
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultMaxResponseSize is the default maximum number of bytes read from a response body.
+const DefaultMaxResponseSize = 64 << 20
+
+// ErrResponseTooLarge returned if the response body is larger than the maximum size.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// ResponseDecoder decodes the API responses directly from the response body.
+//
+// The number of bytes read is limited to [DefaultMaxResponseSize] by default, see [ResponseDecoder.SetMaxSize].
+type ResponseDecoder struct {
+	r           io.Reader
+	maxSize     int64
+	screenshots bool
+	raw         bool
+}
+
+// limitedReader returns ErrResponseTooLarge if more than n bytes read.
+type limitedReader struct {
+	r io.Reader
+	n int64 // Remaining bytes, including the one that exceeds the limit
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+
+	if l.n <= 0 {
+		return n, ErrResponseTooLarge
+	}
+
+	return n, err
+}
+
+func NewResponseDecoder(r io.Reader) *ResponseDecoder {
+	return &ResponseDecoder{r: r, maxSize: DefaultMaxResponseSize}
+}
+
+// SetMaxSize sets the maximum number of bytes read from the response.
+//
+// If n is zero or negative, the size is not limited.
+func (d *ResponseDecoder) SetMaxSize(n int64) {
+	d.maxSize = n
+}
+
+// KeepScreenshots enables the decoding of the screenshots (the full page screenshot and the data of the screenshot and filmstrip audit details).
+//
+// The screenshots are large base64 encoded images, so they are skipped by default.
+func (d *ResponseDecoder) KeepScreenshots() {
+	d.screenshots = true
+}
+
+// KeepRaw keeps the original JSON of the decoded LighthouseResult (see [LighthouseResult.Raw]).
+//
+// The kept JSON is returned by [LighthouseResult.MarshalJSON], so the result is written exactly as it was received.
+// The whole lighthouseResult is read into memory to be kept, so KeepRaw increases the memory usage.
+func (d *ResponseDecoder) KeepRaw() {
+	d.raw = true
+}
+
+// reader returns the size limited reader.
+func (d *ResponseDecoder) reader() io.Reader {
+
+	if d.maxSize <= 0 {
+		return d.r
+	}
+
+	return &limitedReader{r: d.r, n: d.maxSize + 1}
+}
+
+// DecodeLighthouseResult decodes the lighthouseResult field of a PageSpeed API response.
+//
+// The other fields of the response are skipped without decoding.
+// If lighthouseResult is missing or null, returns nil.
+func (d *ResponseDecoder) DecodeLighthouseResult() (*LighthouseResult, error) {
+
+	var r *LighthouseResult
+
+	dec := newJSONDecoder(d.reader())
+
+	err := decodeObject(dec, func(key string) error {
+
+		if key != "lighthouseResult" {
+			return skipValue(dec)
+		}
+
+		var err error
+
+		r, err = d.decodeResult(dec)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// DecodePageSpeedResponse decodes a PageSpeed API response.
+//
+// The fields of the response that are not stored in PageSpeedResponse are skipped without decoding.
+func (d *ResponseDecoder) DecodePageSpeedResponse() (*PageSpeedResponse, error) {
+
+	r := new(PageSpeedResponse)
+
+	dec := newJSONDecoder(d.reader())
+
+	err := decodeObject(dec, func(key string) error {
+
+		switch key {
+		case "id":
+			return dec.Decode(&r.ID)
+		case "analysisUTCTimestamp":
+			var v string
+			if err := dec.Decode(&v); err != nil || v == "" {
+				return err
+			}
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return fmt.Errorf("invalid analysisUTCTimestamp: %w", err)
+			}
+			r.AnalysisTime = t
+			return nil
+		case "loadingExperience":
+			return dec.Decode(&r.LoadingExperience)
+		case "originLoadingExperience":
+			return dec.Decode(&r.OriginLoadingExperience)
+		case "lighthouseResult":
+			var err error
+			r.LighthouseResult, err = d.decodeResult(dec)
+			return err
+		default:
+			return skipValue(dec)
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// decodeResult decodes the next LighthouseResult from dec with the options of d.
+//
+// The original JSON must be read into memory to be kept, so the result is decoded from the stream only if KeepRaw is not set.
+func (d *ResponseDecoder) decodeResult(dec *json.Decoder) (*LighthouseResult, error) {
+
+	if !d.raw {
+		return decodeLighthouseResult(dec, d.screenshots)
+	}
+
+	var raw json.RawMessage
+
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	r, err := decodeLighthouseResult(newJSONDecoder(bytes.NewReader(raw)), d.screenshots)
+	if r != nil {
+		r.raw = raw
+	}
+
+	return r, err
+}
+
+// DecodeError decodes the error field of an error payload.
+//
+// The error responses are small, so the body is kept to be returned by [Error.String].
+// Returns an error if the body is not a JSON error payload (eg.: empty body, HTML from a proxy),
+// use [ResponseDecoder.DecodeErrorResponse] to decode the body of an HTTP response.
+func (d *ResponseDecoder) DecodeError() (*Error, error) {
+
+	data, err := io.ReadAll(d.reader())
+	if err != nil {
+		return nil, fmt.Errorf("read error: %w", err)
+	}
+
+	e, err := errorFromData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if e == nil {
+		return nil, fmt.Errorf("missing error field")
+	}
+
+	return e, nil
+}
+
+// DecodeErrorResponse decodes the error response r, d must read the body of r.
+//
+// The response data is stored in Error and returned by [Error.String].
+// The HTTP status, the selected headers (see [ErrorHeaders]), the request method and the redacted request URL are stored in the Error.
+// If the body is not a JSON error payload (eg.: empty body, HTML from a proxy), the Error is created from the HTTP status.
+//
+// The returned error is not nil only if reading the body fails.
+func (d *ResponseDecoder) DecodeErrorResponse(r *http.Response) (*Error, error) {
+
+	data, err := io.ReadAll(d.reader())
+	if err != nil {
+		return nil, fmt.Errorf("read error: %w", err)
+	}
+
+	e, err := errorFromData(data)
+	if err != nil || e == nil {
+
+		msg := r.Status
+		if msg == "" {
+			msg = strings.TrimSpace(fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)))
+		}
+
+		e = &Error{Code: r.StatusCode, Message: msg, data: data}
+	}
+
+	e.HTTPStatus = r.StatusCode
+
+	for _, k := range ErrorHeaders {
+		if v := r.Header.Values(k); len(v) > 0 {
+			if e.Header == nil {
+				e.Header = make(http.Header)
+			}
+			e.Header[http.CanonicalHeaderKey(k)] = v
+		}
+	}
+
+	if r.Request != nil {
+		e.Method = r.Request.Method
+		if r.Request.URL != nil {
+			e.URL = redactURL(r.Request.URL)
+		}
+	}
+
+	return e, nil
+}
+
+// newJSONDecoder returns a json.Decoder that keeps the numbers as json.Number, so the values are copied without loss (see copyValue).
+func newJSONDecoder(r io.Reader) *json.Decoder {
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	return dec
+}
+
+// decodeObject reads a JSON object from dec and calls fn for every key.
+//
+// fn must read the value of the key from dec.
+func decodeObject(dec *json.Decoder, fn func(key string) error) error {
+
+	null, err := decodeNullableObject(dec, fn)
+	if err == nil && null {
+		return fmt.Errorf("invalid token: null, expected an object")
+	}
+
+	return err
+}
+
+// decodeNullableObject reads a JSON object or null from dec and calls fn for every key.
+//
+// Returns true if the value is null.
+func decodeNullableObject(dec *json.Decoder, fn func(key string) error) (bool, error) {
+
+	t, err := dec.Token()
+	if err != nil {
+		return false, err
+	}
+
+	if t == nil {
+		return true, nil
+	}
+
+	if t != json.Delim('{') {
+		return false, fmt.Errorf("invalid token: %v, expected an object", t)
+	}
+
+	for dec.More() {
+
+		t, err = dec.Token()
+		if err != nil {
+			return false, err
+		}
+
+		if err = fn(t.(string)); err != nil {
+			return false, err
+		}
+	}
+
+	// Closing delim
+	_, err = dec.Token()
+
+	return false, err
+}
+
+// skipValue reads the next value from dec without decoding it.
+func skipValue(dec *json.Decoder) error {
+
+	depth := 0
+
+	for {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// copyValue reads the next value from dec and writes it to buf.
+//
+// If images is false, the data URL images (eg.: the screenshots) are replaced with empty strings,
+// so the large strings are not kept in memory.
+func copyValue(dec *json.Decoder, buf *bytes.Buffer, images bool) error {
+
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch v := t.(type) {
+	case json.Delim:
+
+		end := byte('}')
+		if v == json.Delim('[') {
+			end = ']'
+		}
+
+		buf.WriteByte(byte(v))
+
+		for i := 0; dec.More(); i++ {
+
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if end == '}' {
+
+				k, err := dec.Token()
+				if err != nil {
+					return err
+				}
+
+				if err = writeJSON(buf, k); err != nil {
+					return err
+				}
+
+				buf.WriteByte(':')
+			}
+
+			if err = copyValue(dec, buf, images); err != nil {
+				return err
+			}
+		}
+
+		// Closing delim
+		if _, err = dec.Token(); err != nil {
+			return err
+		}
+
+		buf.WriteByte(end)
+
+		return nil
+
+	case string:
+		if !images && strings.HasPrefix(v, "data:image/") {
+			v = ""
+		}
+		return writeJSON(buf, v)
+
+	default:
+		return writeJSON(buf, v)
+	}
+}
+
+// writeJSON writes the JSON encoding of v to buf.
+func writeJSON(buf *bytes.Buffer, v any) error {
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	buf.Write(data)
+
+	return nil
+}
+
+// decodeLighthouseResult decodes the next LHR from dec.
+//
+// The audits are decoded one by one and the unused fields are skipped, so only the decoded values are kept in memory.
+// If screenshots is false, the full page screenshot and the images in the audit details are skipped.
+// If the LHR is null, returns nil.
+func decodeLighthouseResult(dec *json.Decoder, screenshots bool) (*LighthouseResult, error) {
+
+	r := new(LighthouseResult)
+
+	var (
+		requestedUrl, finalUrl, fetchTime string
+		warnings                          []string
+		runtimeError                      *LighthouseRuntimeError
+	)
+
+	null, err := decodeNullableObject(dec, func(key string) error {
+
+		switch key {
+		case "requestedUrl":
+			return dec.Decode(&requestedUrl)
+		case "finalUrl":
+			return dec.Decode(&finalUrl)
+		case "fetchTime":
+			return dec.Decode(&fetchTime)
+		case "runWarnings":
+			return dec.Decode(&warnings)
+		case "runtimeError":
+			return dec.Decode(&runtimeError)
+		case "audits":
+			return decodeAudits(dec, r, screenshots)
+		case "categories":
+			return dec.Decode(&r.categories)
+		case "categoryGroups":
+			return dec.Decode(&r.categoryGroups)
+		case "timing":
+			v := struct {
+				Total float64 `json:"total"`
+			}{}
+			if err := dec.Decode(&v); err != nil {
+				return err
+			}
+			r.timing = time.Duration(v.Total * 1_000_000)
+			return nil
+		case "fullPageScreenshot":
+			if !screenshots {
+				return skipValue(dec)
+			}
+			v := struct {
+				Screenshot *Screenshot `json:"screenshot"`
+			}{}
+			if err := dec.Decode(&v); err != nil {
+				return fmt.Errorf("invalid fullPageScreenshot: %w", err)
+			}
+			r.fullPageScreenshot = v.Screenshot
+			return nil
+		default:
+			return skipValue(dec)
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if null {
+		return nil, nil
+	}
+
+	for i := range warnings {
+		r.runWarnings = append(r.runWarnings, NewRunWarning(warnings[i]))
+	}
+
+	// Older LHRs report the successful run as NO_ERROR
+	if runtimeError != nil && runtimeError.Code != "NO_ERROR" {
+		runtimeError.Warnings = r.RunWarnings()
+
+		return nil, runtimeError
+	}
+
+	r.requestedUrl, err = url.Parse(requestedUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid requestedUrl: %w", err)
+	}
+
+	r.finalUrl, err = url.Parse(finalUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid finalUrl: %w", err)
+	}
+
+	// fetchTime is missing if it is not selected with the fields parameter
+	if fetchTime != "" {
+		r.fetchTime, err = time.Parse(time.RFC3339, fetchTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fetchTime: %w", err)
+		}
+	}
+
+	return r, nil
+}
+
+// decodeAudits decodes the audits object from dec into r, one audit at a time.
+func decodeAudits(dec *json.Decoder, r *LighthouseResult, screenshots bool) error {
+
+	r.audits = make(map[string]*Audit)
+
+	buf := new(bytes.Buffer)
+
+	_, err := decodeNullableObject(dec, func(id string) error {
+
+		buf.Reset()
+
+		if err := copyValue(dec, buf, screenshots); err != nil {
+			return err
+		}
+
+		var a *Audit
+
+		if err := json.Unmarshal(buf.Bytes(), &a); err != nil {
+			return fmt.Errorf("invalid audit %s: %w", id, err)
+		}
+
+		r.audits[id] = a
+
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,183 @@
+package google_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g0rbe/go-google"
+)
+
+const testScreenshotResponse = `{
+	"id": "https://example.com/",
+	"loadingExperience": {"metrics": {"CUMULATIVE_LAYOUT_SHIFT_SCORE": {"percentile": 0}}},
+	"lighthouseResult": {
+		"requestedUrl": "https://example.com/",
+		"finalUrl": "https://example.com/",
+		"fetchTime": "2024-07-29T16:25:29.029Z",
+		"categories": {"performance": {"id": "performance", "score": 0.5}},
+		"fullPageScreenshot": {"screenshot": {"data": "data:image/webp;base64,AAAA", "width": 412, "height": 823}}
+	},
+	"analysisUTCTimestamp": "2024-07-29T16:25:29.029Z"
+}`
+
+func TestResponseDecoderScreenshots(t *testing.T) {
+
+	res, err := google.NewResponseDecoder(strings.NewReader(testScreenshotResponse)).DecodeLighthouseResult()
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if res.FullPageScreenshot() != nil {
+		t.Fatalf("FAIL: screenshot decoded without request\n")
+	}
+
+	dec := google.NewResponseDecoder(strings.NewReader(testScreenshotResponse))
+	dec.KeepScreenshots()
+
+	res, err = dec.DecodeLighthouseResult()
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if s := res.FullPageScreenshot(); s == nil || s.Width != 412 || s.Data != "data:image/webp;base64,AAAA" {
+		t.Fatalf("FAIL: invalid screenshot: %#v\n", s)
+	}
+}
+
+func TestResponseDecoderMaxSize(t *testing.T) {
+
+	dec := google.NewResponseDecoder(strings.NewReader(testScreenshotResponse))
+	dec.SetMaxSize(64)
+
+	_, err := dec.DecodeLighthouseResult()
+	if !errors.Is(err, google.ErrResponseTooLarge) {
+		t.Fatalf("FAIL: error is not ErrResponseTooLarge: %v\n", err)
+	}
+
+	dec = google.NewResponseDecoder(strings.NewReader(testScreenshotResponse))
+	dec.SetMaxSize(int64(len(testScreenshotResponse)))
+
+	if _, err = dec.DecodeLighthouseResult(); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	dec = google.NewResponseDecoder(strings.NewReader(`{"error":{"code":400,"message":"` + strings.Repeat("a", 128) + `"}}`))
+	dec.SetMaxSize(64)
+
+	_, err = dec.DecodeError()
+	if !errors.Is(err, google.ErrResponseTooLarge) {
+		t.Fatalf("FAIL: error is not ErrResponseTooLarge: %v\n", err)
+	}
+}
+
+func TestResponseDecoderNullResult(t *testing.T) {
+
+	res, err := google.NewResponseDecoder(strings.NewReader(`{"id":"https://example.com/","lighthouseResult":null}`)).DecodeLighthouseResult()
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if res != nil {
+		t.Fatalf("FAIL: result is not nil\n")
+	}
+}
+
+func TestResponseDecoderKeepRaw(t *testing.T) {
+
+	v := struct {
+		LighthouseResult json.RawMessage `json:"lighthouseResult"`
+	}{}
+
+	if err := json.Unmarshal([]byte(testScreenshotResponse), &v); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	dec := google.NewResponseDecoder(strings.NewReader(testScreenshotResponse))
+	dec.KeepRaw()
+
+	res, err := dec.DecodeLighthouseResult()
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if !bytes.Equal(res.Raw(), v.LighthouseResult) {
+		t.Fatalf("FAIL: invalid raw JSON: %s\n", res.Raw())
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	// The screenshot is dropped from the decoded result, but kept in the raw JSON
+	if !strings.Contains(string(data), "data:image/webp;base64,AAAA") {
+		t.Fatalf("FAIL: the raw JSON is not written: %s\n", data)
+	}
+}
+
+func TestResponseDecoderSkipsScreenshots(t *testing.T) {
+
+	// The skipped values are not decoded, so the invalid screenshot is not an error
+	body := `{"lighthouseResult":{"requestedUrl":"https://example.com/","finalUrl":"https://example.com/",` +
+		`"fullPageScreenshot":{"screenshot":{"data":"data:image/webp;base64,AAAA","width":"invalid"}},` +
+		`"audits":{"final-screenshot":{"id":"final-screenshot","score":null,"details":{"type":"screenshot","timing":1,"timestamp":2,"data":"data:image/jpeg;base64,BBBB"}}},` +
+		`"i18n":{"rendererFormattedStrings":{}}}}`
+
+	res, err := google.NewResponseDecoder(strings.NewReader(body)).DecodeLighthouseResult()
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if d, ok := res.Audit("final-screenshot").Details.(*google.ScreenshotDetails); !ok || d.Data != "" || d.Timing != 1 {
+		t.Fatalf("FAIL: invalid screenshot details: %#v\n", res.Audit("final-screenshot").Details)
+	}
+
+	dec := google.NewResponseDecoder(strings.NewReader(body))
+	dec.KeepScreenshots()
+
+	if _, err = dec.DecodeLighthouseResult(); err == nil {
+		t.Fatalf("FAIL: invalid screenshot decoded\n")
+	}
+}
+
+func TestResponseDecoderDecodeErrorResponse(t *testing.T) {
+
+	req, err := http.NewRequest(http.MethodGet, "https://www.googleapis.com/pagespeedonline/v5/runPagespeed?key=secret", nil)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	for _, body := range []string{"", "<html><body>Bad Gateway</body></html>", `{"error":{"code":502,"message":"Bad Gateway"}}`} {
+
+		resp := &http.Response{
+			Status:     "502 Bad Gateway",
+			StatusCode: http.StatusBadGateway,
+			Header:     http.Header{"Retry-After": {"10"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}
+
+		gerr, err := google.NewResponseDecoder(resp.Body).DecodeErrorResponse(resp)
+		if err != nil {
+			t.Fatalf("FAIL: %q: %s\n", body, err)
+		}
+
+		if gerr.Code != 502 || gerr.HTTPStatus != 502 || gerr.RetryAfter() != 10*time.Second || gerr.Method != http.MethodGet || strings.Contains(gerr.URL, "secret") {
+			t.Fatalf("FAIL: %q: invalid error: %#v\n", body, gerr)
+		}
+	}
+
+	if _, err = google.NewResponseDecoder(strings.NewReader("<html></html>")).DecodeError(); err == nil {
+		t.Fatalf("FAIL: no error for HTML payload\n")
+	}
+
+	if _, err = google.NewResponseDecoder(strings.NewReader(`{}`)).DecodeError(); err == nil {
+		t.Fatalf("FAIL: no error for missing error field\n")
+	}
+}
@@ -0,0 +1,288 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Type URLs of the error details
+const (
+	TypeErrorInfo        = "type.googleapis.com/google.rpc.ErrorInfo"
+	TypeQuotaFailure     = "type.googleapis.com/google.rpc.QuotaFailure"
+	TypeRetryInfo        = "type.googleapis.com/google.rpc.RetryInfo"
+	TypeBadRequest       = "type.googleapis.com/google.rpc.BadRequest"
+	TypeHelp             = "type.googleapis.com/google.rpc.Help"
+	TypeLocalizedMessage = "type.googleapis.com/google.rpc.LocalizedMessage"
+)
+
+// ErrorDetail is an element of the details array of an error payload.
+//
+// The type of the detail is one of *ErrorInfo, *QuotaFailure, *RetryInfo, *BadRequest, *Help, *LocalizedMessage or *UnknownDetail.
+//
+// See: https://cloud.google.com/apis/design/errors#error_details
+type ErrorDetail interface {
+	TypeURL() string
+}
+
+// ErrorInfo describes the cause of the error.
+type ErrorInfo struct {
+	Reason   string            `json:"reason"`
+	Domain   string            `json:"domain"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// QuotaViolation describes a single quota violation.
+type QuotaViolation struct {
+	Subject     string `json:"subject"`
+	Description string `json:"description"`
+}
+
+// QuotaFailure describes how a quota check failed.
+type QuotaFailure struct {
+	Violations []QuotaViolation `json:"violations"`
+}
+
+// RetryInfo describes when the client can retry the request.
+type RetryInfo struct {
+	RetryDelay time.Duration
+}
+
+// FieldViolation describes a single invalid request field.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// BadRequest describes the invalid fields of the request.
+type BadRequest struct {
+	FieldViolations []FieldViolation `json:"fieldViolations"`
+}
+
+// HelpLink is a link to the documentation.
+type HelpLink struct {
+	Description string `json:"description"`
+	URL         string `json:"url"`
+}
+
+// Help provides links to the documentation.
+type Help struct {
+	Links []HelpLink `json:"links"`
+}
+
+// LocalizedMessage is the error message in the given locale.
+type LocalizedMessage struct {
+	Locale  string `json:"locale"`
+	Message string `json:"message"`
+}
+
+// UnknownDetail stores a detail with an unknown type, or a detail that cannot be unmarshaled as its type, as is.
+type UnknownDetail struct {
+	Type string
+	Data json.RawMessage
+}
+
+func (d *ErrorInfo) TypeURL() string        { return TypeErrorInfo }
+func (d *QuotaFailure) TypeURL() string     { return TypeQuotaFailure }
+func (d *RetryInfo) TypeURL() string        { return TypeRetryInfo }
+func (d *BadRequest) TypeURL() string       { return TypeBadRequest }
+func (d *Help) TypeURL() string             { return TypeHelp }
+func (d *LocalizedMessage) TypeURL() string { return TypeLocalizedMessage }
+func (d *UnknownDetail) TypeURL() string    { return d.Type }
+
+// UnmarshalJSON parses the retryDelay as a google.protobuf.Duration (eg.: "30s").
+func (d *RetryInfo) UnmarshalJSON(data []byte) error {
+
+	v := struct {
+		RetryDelay string `json:"retryDelay"`
+	}{}
+
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+
+	if v.RetryDelay == "" {
+		d.RetryDelay = 0
+		return nil
+	}
+
+	d.RetryDelay, err = time.ParseDuration(v.RetryDelay)
+	if err != nil {
+		return fmt.Errorf("invalid retryDelay: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON formats the retryDelay as a google.protobuf.Duration (eg.: "30s").
+func (d *RetryInfo) MarshalJSON() ([]byte, error) {
+
+	v := struct {
+		RetryDelay string `json:"retryDelay"`
+	}{
+		RetryDelay: strconv.FormatFloat(d.RetryDelay.Seconds(), 'f', -1, 64) + "s",
+	}
+
+	return json.Marshal(v)
+}
+
+// MarshalJSON returns the original detail.
+func (d *UnknownDetail) MarshalJSON() ([]byte, error) {
+
+	if len(d.Data) == 0 {
+		return json.Marshal(map[string]string{"@type": d.Type})
+	}
+
+	return d.Data, nil
+}
+
+// errorDetailJSON marshals the detail with the @type field.
+type errorDetailJSON struct {
+	d ErrorDetail
+}
+
+func (v errorDetailJSON) MarshalJSON() ([]byte, error) {
+
+	if _, ok := v.d.(*UnknownDetail); ok {
+		return json.Marshal(v.d)
+	}
+
+	data, err := json.Marshal(v.d)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]json.RawMessage)
+
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	m["@type"], err = json.Marshal(v.d.TypeURL())
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(m)
+}
+
+// unmarshalErrorDetail unmarshals a detail based on its @type.
+//
+// If the type is unknown or the detail cannot be unmarshaled as its type, returns the detail as *UnknownDetail,
+// so a single detail does not fail the whole error payload.
+func unmarshalErrorDetail(data []byte) ErrorDetail {
+
+	t := struct {
+		Type string `json:"@type"`
+	}{}
+
+	// The type is empty if data is not an object
+	_ = json.Unmarshal(data, &t)
+
+	var d ErrorDetail
+
+	switch t.Type {
+	case TypeErrorInfo:
+		d = new(ErrorInfo)
+	case TypeQuotaFailure:
+		d = new(QuotaFailure)
+	case TypeRetryInfo:
+		d = new(RetryInfo)
+	case TypeBadRequest:
+		d = new(BadRequest)
+	case TypeHelp:
+		d = new(Help)
+	case TypeLocalizedMessage:
+		d = new(LocalizedMessage)
+	default:
+		return &UnknownDetail{Type: t.Type, Data: append(json.RawMessage(nil), data...)}
+	}
+
+	if err := json.Unmarshal(data, d); err != nil {
+		return &UnknownDetail{Type: t.Type, Data: append(json.RawMessage(nil), data...)}
+	}
+
+	return d
+}
+
+// findDetail returns the first detail of e with type T.
+func findDetail[T ErrorDetail](e *Error) T {
+
+	var zero T
+
+	for i := range e.Details {
+		if d, ok := e.Details[i].(T); ok {
+			return d
+		}
+	}
+
+	return zero
+}
+
+// ErrorInfo returns the ErrorInfo detail.
+//
+// If detail not found, returns nil.
+func (e *Error) ErrorInfo() *ErrorInfo {
+	return findDetail[*ErrorInfo](e)
+}
+
+// QuotaFailure returns the QuotaFailure detail.
+//
+// If detail not found, returns nil.
+func (e *Error) QuotaFailure() *QuotaFailure {
+	return findDetail[*QuotaFailure](e)
+}
+
+// RetryInfo returns the RetryInfo detail.
+//
+// If detail not found, returns nil.
+func (e *Error) RetryInfo() *RetryInfo {
+	return findDetail[*RetryInfo](e)
+}
+
+// BadRequest returns the BadRequest detail.
+//
+// If detail not found, returns nil.
+func (e *Error) BadRequest() *BadRequest {
+	return findDetail[*BadRequest](e)
+}
+
+// Help returns the Help detail.
+//
+// If detail not found, returns nil.
+func (e *Error) Help() *Help {
+	return findDetail[*Help](e)
+}
+
+// LocalizedMessage returns the LocalizedMessage detail.
+//
+// If detail not found, returns nil.
+func (e *Error) LocalizedMessage() *LocalizedMessage {
+	return findDetail[*LocalizedMessage](e)
+}
+
+// RetryDelay returns the retry delay from the RetryInfo detail.
+//
+// If detail not found, returns the duration from the Retry-After header (see [Error.RetryAfter]).
+func (e *Error) RetryDelay() time.Duration {
+
+	if d := e.RetryInfo(); d != nil {
+		return d.RetryDelay
+	}
+
+	return e.RetryAfter()
+}
+
+// QuotaMetric returns the quota metric that failed from the quota_metric metadata of the ErrorInfo detail.
+//
+// If not found, returns an empty string.
+func (e *Error) QuotaMetric() string {
+
+	if d := e.ErrorInfo(); d != nil {
+		return d.Metadata["quota_metric"]
+	}
+
+	return ""
+}
@@ -1,11 +1,16 @@
 package google
 
+//go:generate go run ./internal/cmd/gencatalogue -dir testdata/errors -out catalogue.go
+
 import (
 	"encoding/json"
 	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
+	"time"
 )
 
 type GoogleError struct {
@@ -29,8 +34,14 @@ func (e *GoogleError) Error() string {
 // The Domain, Reason, LocationType and the Location fields must be equal.
 // If the Message field is not equal, than the Message field of target
 // can be used used as a regexp pattern to allow matching errors with dynamic fields (eg.: ErrLighthouseInvalidUrl)
+//
+// If target is a *LighthouseRuntimeError, the code in the Message must be equal (eg.: ErrLighthouseRuntimeNoFCP).
 func (e *GoogleError) Is(target error) bool {
 
+	if t, ok := target.(*LighthouseRuntimeError); ok {
+		return e.Domain == "lighthouse" && lighthouseCode(e.Message) == t.Code
+	}
+
 	t, ok := target.(*GoogleError)
 	if !ok {
 		// Not *GoogleError
@@ -73,32 +84,48 @@ func (e *GoogleError) Is(target error) bool {
 //
 // See: https://developers.google.com/webmaster-tools/v1/errors
 type Error struct {
-	Code    int     `json:"code"`
-	Message string  `json:"message"`
-	Errors  []error `json:"errors"`
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Errors  []error       `json:"errors"`
+	Status  string        `json:"status"`  // Canonical error code (eg.: "RESOURCE_EXHAUSTED")
+	Details []ErrorDetail `json:"details"` // See ErrorDetail
+
+	HTTPStatus int         `json:"-"` // Status code of the HTTP response
+	Header     http.Header `json:"-"` // Selected headers of the HTTP response (see ErrorHeaders)
+	Method     string      `json:"-"` // Method of the HTTP request
+	URL        string      `json:"-"` // URL of the HTTP request with the credentials redacted
 
 	data []byte
 }
 
+// ErrorHeaders are the response headers stored in Error.Header by [ResponseDecoder.DecodeErrorResponse].
+var ErrorHeaders = []string{"Retry-After", "Content-Type", "Date", "Www-Authenticate", "Server"}
+
+// redactedParams are the sensitive query params redacted from Error.URL and LighthouseError.Params.
+var redactedParams = []string{"key", "access_token", "token", "captchaToken", "quotaUser", "userIp"}
+
 func NewError(code int, message string) *Error {
 	return &Error{Code: code, Message: message}
 }
 
 // ErrorFromResponse reads the response body and returns the Error.
 //
-// The response data is stored in Error and returned by [String]
+// See [ResponseDecoder.DecodeErrorResponse] for the details.
+// The size of the body is limited to [DefaultMaxResponseSize], use [Client.NewDecoder] to change it.
 func ErrorFromResponse(r *http.Response) (*Error, error) {
+	return NewResponseDecoder(r.Body).DecodeErrorResponse(r)
+}
 
-	data, err := io.ReadAll(r.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read error: %w", err)
-	}
+// errorFromData unmarshals the error field of an error payload.
+//
+// If the error field is missing, returns nil.
+func errorFromData(data []byte) (*Error, error) {
 
 	v := struct {
 		Err *Error `json:"error"`
 	}{}
 
-	err = json.Unmarshal(data, &v)
+	err := json.Unmarshal(data, &v)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal error: %w", err)
 	}
@@ -110,6 +137,51 @@ func ErrorFromResponse(r *http.Response) (*Error, error) {
 	return v.Err, nil
 }
 
+// redactURL returns u as a string with the credentials replaced with "REDACTED".
+func redactURL(u *url.URL) string {
+
+	c := *u
+
+	if c.User != nil {
+		c.User = url.User("REDACTED")
+	}
+
+	q := c.Query()
+
+	for _, k := range redactedParams {
+		if q.Has(k) {
+			q.Set(k, "REDACTED")
+		}
+	}
+
+	c.RawQuery = q.Encode()
+
+	return c.String()
+}
+
+// RetryAfter returns the duration from the Retry-After header.
+//
+// If the header is missing or invalid, returns 0.
+func (e *Error) RetryAfter() time.Duration {
+
+	v := e.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if s, err := strconv.Atoi(v); err == nil {
+		return time.Duration(s) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
 func (e *Error) Unwrap() []error {
 	return e.Errors
 }
@@ -127,6 +199,10 @@ func (e *Error) Error() string {
 	return e.Message
 }
 
+// UnmarshalJSON implements the [json.Unmarshaler].
+//
+// Only the fields of the API error payload are read, the response metadata (HTTPStatus, Header, Method and URL)
+// is never taken from the server-controlled JSON.
 func (e *Error) UnmarshalJSON(data []byte) error {
 
 	v := struct {
@@ -139,6 +215,8 @@ func (e *Error) UnmarshalJSON(data []byte) error {
 			LocationType string `json:"locationType"`
 			Location     string `json:"location"`
 		} `json:"errors"`
+		Status  string            `json:"status"`
+		Details []json.RawMessage `json:"details"`
 	}{}
 
 	err := json.Unmarshal(data, &v)
@@ -150,12 +228,190 @@ func (e *Error) UnmarshalJSON(data []byte) error {
 
 	r.Code = v.Code
 	r.Message = v.Message
+	r.Status = v.Status
 
 	for i := range v.Errors {
 		r.Errors = append(r.Errors, NewGoogleError(v.Errors[i].Domain, v.Errors[i].Reason, v.Errors[i].Message, v.Errors[i].LocationType, v.Errors[i].Location))
 	}
 
+	for i := range v.Details {
+		r.Details = append(r.Details, unmarshalErrorDetail(v.Details[i]))
+	}
+
 	*e = *(*Error)(r)
 
 	return nil
 }
+
+// errorPayload is the JSON representation of the API error payload.
+type errorPayload struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Errors  []*GoogleError    `json:"errors,omitempty"`
+	Status  string            `json:"status,omitempty"`
+	Details []errorDetailJSON `json:"details,omitempty"`
+}
+
+// payload returns the API error payload of e.
+//
+// The elements of Errors that are not *GoogleError are stored with the message only.
+func (e *Error) payload() errorPayload {
+
+	v := errorPayload{Code: e.Code, Message: e.Message, Status: e.Status}
+
+	for i := range e.Errors {
+
+		if gerr, ok := e.Errors[i].(*GoogleError); ok {
+			v.Errors = append(v.Errors, gerr)
+		} else {
+			v.Errors = append(v.Errors, &GoogleError{Message: e.Errors[i].Error()})
+		}
+	}
+
+	for i := range e.Details {
+		v.Details = append(v.Details, errorDetailJSON{e.Details[i]})
+	}
+
+	return v
+}
+
+// MarshalJSON implements the [json.Marshaler].
+//
+// The output is the API error payload with the response metadata (HTTPStatus, Header, Method, URL and the RetryDelay),
+// the same fields as in [Error.LogValue].
+// [Error.UnmarshalJSON] reads back only the API error payload.
+func (e *Error) MarshalJSON() ([]byte, error) {
+
+	v := struct {
+		errorPayload
+		HTTPStatus int           `json:"httpStatus,omitempty"`
+		Header     http.Header   `json:"header,omitempty"`
+		Method     string        `json:"method,omitempty"`
+		URL        string        `json:"url,omitempty"`
+		RetryDelay time.Duration `json:"retryDelay,omitempty"` // Nanoseconds
+	}{
+		errorPayload: e.payload(),
+		HTTPStatus:   e.HTTPStatus,
+		Header:       e.Header,
+		Method:       e.Method,
+		URL:          e.URL,
+		RetryDelay:   e.RetryDelay(),
+	}
+
+	return json.Marshal(v)
+}
+
+// errorRecord is the persistence format of Error: the output of [Error.MarshalJSON].
+//
+// Unlike [Error.UnmarshalJSON], errorRecord restores the response metadata, so it must be used only for trusted JSON
+// (eg.: the output of [LighthouseError.MarshalJSON]).
+type errorRecord struct {
+	err *Error
+}
+
+func (r errorRecord) MarshalJSON() ([]byte, error) {
+	return r.err.MarshalJSON()
+}
+
+func (r *errorRecord) UnmarshalJSON(data []byte) error {
+
+	e := new(Error)
+
+	err := json.Unmarshal(data, e)
+	if err != nil {
+		return err
+	}
+
+	v := struct {
+		HTTPStatus int         `json:"httpStatus"`
+		Header     http.Header `json:"header"`
+		Method     string      `json:"method"`
+		URL        string      `json:"url"`
+	}{}
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	e.HTTPStatus = v.HTTPStatus
+	e.Header = v.Header
+	e.Method = v.Method
+	e.URL = v.URL
+
+	r.err = e
+
+	return nil
+}
+
+// LogValue implements the [slog.LogValuer].
+func (e *Error) LogValue() slog.Value {
+
+	attrs := []slog.Attr{slog.Int("code", e.Code), slog.String("message", e.Message)}
+
+	if e.Status != "" {
+		attrs = append(attrs, slog.String("status", e.Status))
+	}
+
+	if e.HTTPStatus != 0 {
+		attrs = append(attrs, slog.Int("httpStatus", e.HTTPStatus))
+	}
+
+	if e.Method != "" {
+		attrs = append(attrs, slog.String("method", e.Method))
+	}
+
+	if e.URL != "" {
+		attrs = append(attrs, slog.String("url", e.URL))
+	}
+
+	if d := e.RetryDelay(); d > 0 {
+		attrs = append(attrs, slog.Duration("retryDelay", d))
+	}
+
+	if len(e.Errors) > 0 {
+
+		errs := make([]slog.Attr, 0, len(e.Errors))
+
+		for i := range e.Errors {
+			errs = append(errs, slog.Any(strconv.Itoa(i), e.Errors[i]))
+		}
+
+		attrs = append(attrs, slog.Attr{Key: "errors", Value: slog.GroupValue(errs...)})
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// MarshalJSON implements the [json.Marshaler], the empty fields are omitted.
+func (e *GoogleError) MarshalJSON() ([]byte, error) {
+
+	v := struct {
+		Domain       string `json:"domain,omitempty"`
+		Reason       string `json:"reason,omitempty"`
+		Message      string `json:"message"`
+		LocationType string `json:"locationType,omitempty"`
+		Location     string `json:"location,omitempty"`
+	}(*e)
+
+	return json.Marshal(v)
+}
+
+// LogValue implements the [slog.LogValuer], the empty fields are omitted.
+func (e *GoogleError) LogValue() slog.Value {
+
+	attrs := make([]slog.Attr, 0, 5)
+
+	for _, a := range []slog.Attr{
+		slog.String("domain", e.Domain),
+		slog.String("reason", e.Reason),
+		slog.String("message", e.Message),
+		slog.String("locationType", e.LocationType),
+		slog.String("location", e.Location),
+	} {
+		if a.Value.String() != "" {
+			attrs = append(attrs, a)
+		}
+	}
+
+	return slog.GroupValue(attrs...)
+}
@@ -1,8 +1,15 @@
 package google_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/g0rbe/go-google"
 )
@@ -71,3 +78,294 @@ func TestLighthouseErrorIsErrLighthouseUnprocessable(t *testing.T) {
 		t.Fatalf("FAIL: error is not ErrLighthouseUnprocessable\n")
 	}
 }
+
+const testQuotaErrorResponse = `{
+  "error": {
+    "code": 429,
+    "message": "Quota exceeded for quota metric 'Queries' and limit 'Queries per minute' of service 'pagespeedonline.googleapis.com' for consumer 'project_number:000000000000'.",
+    "errors": [
+      {
+        "message": "Quota exceeded for quota metric 'Queries' and limit 'Queries per minute' of service 'pagespeedonline.googleapis.com' for consumer 'project_number:000000000000'.",
+        "domain": "global",
+        "reason": "rateLimitExceeded"
+      }
+    ],
+    "status": "RESOURCE_EXHAUSTED",
+    "details": [
+      {
+        "@type": "type.googleapis.com/google.rpc.ErrorInfo",
+        "reason": "RATE_LIMIT_EXCEEDED",
+        "domain": "googleapis.com",
+        "metadata": {
+          "quota_metric": "pagespeedonline.googleapis.com/default",
+          "quota_limit": "defaultPerMinutePerProject",
+          "service": "pagespeedonline.googleapis.com",
+          "consumer": "projects/000000000000"
+        }
+      },
+      {
+        "@type": "type.googleapis.com/google.rpc.Help",
+        "links": [
+          {
+            "description": "Request a higher quota limit.",
+            "url": "https://cloud.google.com/docs/quota#requesting_higher_quota"
+          }
+        ]
+      },
+      {
+        "@type": "type.googleapis.com/google.rpc.RetryInfo",
+        "retryDelay": "30s"
+      },
+      {
+        "@type": "type.googleapis.com/google.rpc.Unknown",
+        "value": 1
+      }
+    ]
+  }
+}`
+
+func TestErrorDetails(t *testing.T) {
+
+	resp := &http.Response{StatusCode: 429, Body: io.NopCloser(strings.NewReader(testQuotaErrorResponse))}
+
+	err, rerr := google.ErrorFromResponse(resp)
+	if rerr != nil {
+		t.Fatalf("FAIL: %s\n", rerr)
+	}
+
+	if err.Status != "RESOURCE_EXHAUSTED" {
+		t.Fatalf("FAIL: invalid Status: %s\n", err.Status)
+	}
+
+	if len(err.Details) != 4 {
+		t.Fatalf("FAIL: invalid number of details: %d\n", len(err.Details))
+	}
+
+	if err.RetryDelay() != 30*time.Second {
+		t.Fatalf("FAIL: invalid RetryDelay: %s\n", err.RetryDelay())
+	}
+
+	if err.QuotaMetric() != "pagespeedonline.googleapis.com/default" {
+		t.Fatalf("FAIL: invalid QuotaMetric: %s\n", err.QuotaMetric())
+	}
+
+	if h := err.Help(); h == nil || len(h.Links) != 1 || h.Links[0].URL != "https://cloud.google.com/docs/quota#requesting_higher_quota" {
+		t.Fatalf("FAIL: invalid Help: %#v\n", h)
+	}
+
+	if err.QuotaFailure() != nil {
+		t.Fatalf("FAIL: QuotaFailure is not nil\n")
+	}
+
+	if err.Details[3].TypeURL() != "type.googleapis.com/google.rpc.Unknown" {
+		t.Fatalf("FAIL: invalid unknown detail: %s\n", err.Details[3].TypeURL())
+	}
+
+	if !errors.Is(err, google.ErrLighthouseRateLimitExceeded) {
+		t.Fatalf("FAIL: error is not ErrLighthouseRateLimitExceeded\n")
+	}
+}
+
+func TestErrorDetailsMalformed(t *testing.T) {
+
+	// The metadata of ErrorInfo must be a map of strings
+	body := strings.Replace(testQuotaErrorResponse, `"service": "pagespeedonline.googleapis.com",`, `"service": 1,`, 1)
+
+	resp := &http.Response{StatusCode: 429, Status: "429 Too Many Requests", Body: io.NopCloser(strings.NewReader(body))}
+
+	gerr, err := google.ErrorFromResponse(resp)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if gerr.Message == "429 Too Many Requests" || len(gerr.Errors) != 1 || !errors.Is(gerr, google.ErrLighthouseRateLimitExceeded) {
+		t.Fatalf("FAIL: payload is not decoded: %#v\n", gerr)
+	}
+
+	d, ok := gerr.Details[0].(*google.UnknownDetail)
+	if !ok || d.Type != google.TypeErrorInfo || !strings.Contains(string(d.Data), `"service": 1`) {
+		t.Fatalf("FAIL: malformed detail is not UnknownDetail: %#v\n", gerr.Details[0])
+	}
+
+	if gerr.ErrorInfo() != nil || gerr.Help() == nil {
+		t.Fatalf("FAIL: invalid details: %#v\n", gerr.Details)
+	}
+}
+
+func TestErrorFromResponseMetadata(t *testing.T) {
+
+	req, _ := http.NewRequest(http.MethodGet, "https://www.googleapis.com/pagespeedonline/v5/runPagespeed?key=secret&url=https%3A%2F%2Fexample.com%2F", nil)
+
+	resp := &http.Response{
+		Status:     "502 Bad Gateway",
+		StatusCode: 502,
+		Header:     http.Header{"Content-Type": {"text/html"}, "Retry-After": {"120"}, "Set-Cookie": {"a=b"}},
+		Body:       io.NopCloser(strings.NewReader("<html><body>Bad Gateway</body></html>")),
+		Request:    req,
+	}
+
+	err, rerr := google.ErrorFromResponse(resp)
+	if rerr != nil {
+		t.Fatalf("FAIL: %s\n", rerr)
+	}
+
+	if err.Code != 502 || err.HTTPStatus != 502 || err.Message != "502 Bad Gateway" {
+		t.Fatalf("FAIL: invalid error: %d %d %s\n", err.Code, err.HTTPStatus, err.Message)
+	}
+
+	if err.String() != "<html><body>Bad Gateway</body></html>" {
+		t.Fatalf("FAIL: invalid body: %s\n", err.String())
+	}
+
+	if err.Header.Get("Set-Cookie") != "" || err.Header.Get("Content-Type") != "text/html" {
+		t.Fatalf("FAIL: invalid headers: %v\n", err.Header)
+	}
+
+	if err.RetryDelay() != 120*time.Second {
+		t.Fatalf("FAIL: invalid RetryDelay: %s\n", err.RetryDelay())
+	}
+
+	if err.Method != http.MethodGet || strings.Contains(err.URL, "secret") || !strings.Contains(err.URL, "key=REDACTED") {
+		t.Fatalf("FAIL: invalid request: %s %s\n", err.Method, err.URL)
+	}
+
+	// Empty body
+	resp = &http.Response{StatusCode: 503, Body: io.NopCloser(strings.NewReader(""))}
+
+	err, rerr = google.ErrorFromResponse(resp)
+	if rerr != nil {
+		t.Fatalf("FAIL: %s\n", rerr)
+	}
+
+	if err.Code != 503 || err.Message != "503 Service Unavailable" {
+		t.Fatalf("FAIL: invalid error: %d %s\n", err.Code, err.Message)
+	}
+}
+
+func TestErrorMarshalJSON(t *testing.T) {
+
+	resp := &http.Response{StatusCode: 429, Header: http.Header{"Retry-After": {"30"}}, Body: io.NopCloser(strings.NewReader(testQuotaErrorResponse))}
+
+	gerr, err := google.ErrorFromResponse(resp)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	lerr := google.NewLighthouseError("https://example.com/", gerr)
+	lerr.Params = []google.LighthouseParam{google.LighthouseStrategyMobile, google.UserProject("project-1")}
+	lerr.Credential = "...Fk0Q"
+	lerr.Attempt = 1
+	lerr.Elapsed = 1500 * time.Millisecond
+	lerr.HTTPStatus = 429
+
+	data, err := json.Marshal(lerr)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	v := new(google.LighthouseError)
+
+	if err = json.Unmarshal(data, v); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if v.URL != "https://example.com/" || v.Credential != "...Fk0Q" || v.Elapsed != 1500*time.Millisecond || v.HTTPStatus != 429 {
+		t.Fatalf("FAIL: invalid *LighthouseError: %#v\n", v)
+	}
+
+	if len(v.Params) != 2 || !v.Params[1].IsHeader() || v.Params[0].Value() != lerr.Params[0].Value() {
+		t.Fatalf("FAIL: invalid Params: %v\n", v.Params)
+	}
+
+	if !errors.Is(v, google.ErrLighthouseRateLimitExceeded) {
+		t.Fatalf("FAIL: error is not ErrLighthouseRateLimitExceeded\n")
+	}
+
+	var verr *google.Error
+	if !errors.As(v, &verr) {
+		t.Fatalf("FAIL: error is not *Error\n")
+	}
+
+	if verr.Code != 429 || verr.HTTPStatus != 429 || verr.Status != "RESOURCE_EXHAUSTED" || verr.Header.Get("Retry-After") != "30" {
+		t.Fatalf("FAIL: invalid *Error: %#v\n", verr)
+	}
+
+	if verr.RetryDelay() != 30*time.Second || verr.QuotaMetric() != "pagespeedonline.googleapis.com/default" || len(verr.Details) != len(gerr.Details) {
+		t.Fatalf("FAIL: invalid details: %#v\n", verr.Details)
+	}
+
+	again, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if !bytes.Equal(data, again) {
+		t.Fatalf("FAIL: JSON differs:\n%s\n%s\n", data, again)
+	}
+}
+
+func TestErrorUnmarshalJSONMetadata(t *testing.T) {
+
+	// The metadata in the API payload must be ignored
+	body := `{"error":{"code":400,"message":"invalid","httpStatus":200,"header":{"Retry-After":["1"]},"method":"POST","url":"https://attacker.example/"}}`
+
+	resp := &http.Response{StatusCode: 400, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+
+	gerr, err := google.ErrorFromResponse(resp)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if gerr.HTTPStatus != 400 || gerr.Header != nil || gerr.Method != "" || gerr.URL != "" {
+		t.Fatalf("FAIL: metadata is read from the payload: %#v\n", gerr)
+	}
+
+	data, err := json.Marshal(gerr)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	// The metadata is written, but it is not read back
+	if !strings.Contains(string(data), `"httpStatus":400`) {
+		t.Fatalf("FAIL: metadata is not written: %s\n", data)
+	}
+
+	v := new(google.Error)
+
+	if err = json.Unmarshal(data, v); err != nil || v.HTTPStatus != 0 {
+		t.Fatalf("FAIL: metadata is read back: %#v, %v\n", v, err)
+	}
+}
+
+func TestErrorLogValue(t *testing.T) {
+
+	buf := new(bytes.Buffer)
+
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+
+	logger.Error("lighthouse failed", "error", google.NewLighthouseError("https://example.com/", &google.Error{
+		Code:    500,
+		Message: "Unable to process request. Please wait a while and try again.",
+		Errors:  []error{google.NewGoogleError("global", "internalError", "Unable to process request. Please wait a while and try again.", "", "")},
+	}))
+
+	v := struct {
+		Error struct {
+			URL   string `json:"url"`
+			Error struct {
+				Code   int `json:"code"`
+				Errors map[string]struct {
+					Reason string `json:"reason"`
+				} `json:"errors"`
+			} `json:"error"`
+		} `json:"error"`
+	}{}
+
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatalf("FAIL: %s: %s\n", err, buf)
+	}
+
+	if v.Error.URL != "https://example.com/" || v.Error.Error.Code != 500 || v.Error.Error.Errors["0"].Reason != "internalError" {
+		t.Fatalf("FAIL: invalid log: %s\n", buf)
+	}
+}
@@ -0,0 +1,200 @@
+// Command gencatalogue generates the catalogue of the known Google API errors from the recorded responses.
+//
+// Every fixture is a JSON file with the following fields:
+//
+//	name:    The name of the sentinel error (eg.: "ErrLighthouseInvalidKey")
+//	doc:     The doc comment of the sentinel (optional)
+//	dynamic: The dynamic substrings of the recorded message (optional, eg.: the value of an invalid parameter)
+//	status:  The HTTP status of the recorded response
+//	body:    The recorded response body
+//
+// The sentinel is created from the first element of the errors array of the body.
+// If the message has dynamic substrings, the message of the sentinel is a regexp pattern derived from the recorded message:
+// the message is escaped and the dynamic substrings are replaced with ".*".
+//
+// Usage:
+//
+//	go run ./internal/cmd/gencatalogue -dir testdata/errors -out catalogue.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type fixture struct {
+	Name    string   `json:"name"`
+	Doc     string   `json:"doc"`
+	Dynamic []string `json:"dynamic"`
+	Status  int      `json:"status"`
+	Body    struct {
+		Error struct {
+			Errors []struct {
+				Domain       string `json:"domain"`
+				Reason       string `json:"reason"`
+				Message      string `json:"message"`
+				LocationType string `json:"locationType"`
+				Location     string `json:"location"`
+			} `json:"errors"`
+		} `json:"error"`
+	} `json:"body"`
+
+	file string
+}
+
+func readFixtures(dir string) ([]*fixture, error) {
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+
+	v := make([]*fixture, 0, len(files))
+
+	for i := range files {
+
+		data, err := os.ReadFile(files[i])
+		if err != nil {
+			return nil, err
+		}
+
+		f := &fixture{file: filepath.ToSlash(files[i])}
+
+		if err = json.Unmarshal(data, f); err != nil {
+			return nil, fmt.Errorf("%s: %w", files[i], err)
+		}
+
+		if f.Name == "" {
+			return nil, fmt.Errorf("%s: missing name", files[i])
+		}
+
+		if len(f.Body.Error.Errors) == 0 {
+			return nil, fmt.Errorf("%s: missing errors", files[i])
+		}
+
+		for _, d := range f.Dynamic {
+			if d == "" || !strings.Contains(f.Body.Error.Errors[0].Message, d) {
+				return nil, fmt.Errorf("%s: dynamic substring %q is not in the message", files[i], d)
+			}
+		}
+
+		v = append(v, f)
+	}
+
+	return v, nil
+}
+
+func generate(fixtures []*fixture) ([]byte, error) {
+
+	b := new(bytes.Buffer)
+
+	fmt.Fprintf(b, "// Code generated by gencatalogue; DO NOT EDIT.\n\n")
+	fmt.Fprintf(b, "package google\n\n")
+	fmt.Fprintf(b, "// The known errors of the supported APIs, generated from the recorded responses in testdata/errors.\n")
+	fmt.Fprintf(b, "var (\n")
+
+	for _, f := range fixtures {
+
+		e := f.Body.Error.Errors[0]
+
+		msg := pattern(e.Message, f.Dynamic)
+
+		fmt.Fprintf(b, "\n")
+
+		if f.Doc != "" {
+			for _, l := range strings.Split(f.Doc, "\n") {
+				fmt.Fprintf(b, "\t%s\n", strings.TrimSpace("// "+l))
+			}
+			fmt.Fprintf(b, "\t//\n")
+		}
+
+		fmt.Fprintf(b, "\t// Recorded in %s\n", f.file)
+
+		fmt.Fprintf(b, "\t%s = &GoogleError{\n", f.Name)
+		fmt.Fprintf(b, "\t\tMessage: %s,\n", quote(msg))
+
+		for _, kv := range [][2]string{{"Domain", e.Domain}, {"Reason", e.Reason}, {"Location", e.Location}, {"LocationType", e.LocationType}} {
+			if kv[1] != "" {
+				fmt.Fprintf(b, "\t\t%s: %s,\n", kv[0], strconv.Quote(kv[1]))
+			}
+		}
+
+		fmt.Fprintf(b, "\t}\n")
+	}
+
+	fmt.Fprintf(b, ")\n\n")
+
+	fmt.Fprintf(b, "// ErrorCatalogue maps the names of the known errors to the sentinels.\n")
+	fmt.Fprintf(b, "var ErrorCatalogue = map[string]*GoogleError{\n")
+
+	for _, f := range fixtures {
+		fmt.Fprintf(b, "\t%s: %s,\n", strconv.Quote(f.Name), f.Name)
+	}
+
+	fmt.Fprintf(b, "}\n")
+
+	return format.Source(b.Bytes())
+}
+
+// pattern returns the message of the sentinel.
+//
+// If dynamic is empty, returns message, otherwise an anchored regexp pattern of message with the dynamic substrings replaced with ".*".
+func pattern(message string, dynamic []string) string {
+
+	if len(dynamic) == 0 {
+		return message
+	}
+
+	p := regexp.QuoteMeta(message)
+
+	for _, d := range dynamic {
+		p = strings.ReplaceAll(p, regexp.QuoteMeta(d), ".*")
+	}
+
+	return "^" + p + "$"
+}
+
+// quote returns s as a raw string literal if possible.
+func quote(s string) string {
+
+	if strings.ContainsAny(s, "`\n") || !strings.Contains(s, `\`) {
+		return strconv.Quote(s)
+	}
+
+	return "`" + s + "`"
+}
+
+func main() {
+
+	dir := flag.String("dir", "testdata/errors", "Directory of the fixtures")
+	out := flag.String("out", "catalogue.go", "Output file")
+	flag.Parse()
+
+	fixtures, err := readFixtures(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read fixtures: %s\n", err)
+		os.Exit(1)
+	}
+
+	data, err := generate(fixtures)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate: %s\n", err)
+		os.Exit(1)
+	}
+
+	if err = os.WriteFile(*out, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write %s: %s\n", *out, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,265 @@
+// Package fakeapi implements a fake PageSpeed API server for the offline tests.
+//
+// The server answers every request with the next scripted Response, or with the default Response if the script is empty.
+// The faults of the real API can be scripted: latency, error statuses, truncated and non-JSON bodies, runtimeError
+// payloads and the exhausted quota of an API key.
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBody is a minimal successful PageSpeed API response.
+const DefaultBody = `{
+  "id": "https://example.com/",
+  "lighthouseResult": {
+    "requestedUrl": "https://example.com/",
+    "finalUrl": "https://example.com/",
+    "lighthouseVersion": "12.0.0",
+    "fetchTime": "2024-07-29T16:25:29.029Z",
+    "runWarnings": [],
+    "audits": {},
+    "categories": {"performance": {"id": "performance", "title": "Performance", "score": 0.99}},
+    "timing": {"total": 1234.5}
+  },
+  "analysisUTCTimestamp": "2024-07-29T16:25:29.029Z"
+}`
+
+// Response is a scripted response of the server.
+type Response struct {
+	Latency  time.Duration // The delay before the response is written, cut short if the request is cancelled
+	Status   int           // The status code, 200 if zero
+	Header   http.Header   // Additional headers
+	Body     string        // The body, DefaultBody if empty and Status is 200
+	Truncate int           // If positive, the connection is closed after the first Truncate bytes of the body
+}
+
+// Error returns a Google API error response with the given status code, status and reason.
+func Error(code int, status, reason, message string) Response {
+
+	body := fmt.Sprintf(`{"error":{"code":%d,"message":%q,"errors":[{"message":%q,"domain":"global","reason":%q}],"status":%q}}`,
+		code, message, message, reason, status)
+
+	return Response{Status: code, Header: http.Header{"Content-Type": {"application/json; charset=UTF-8"}}, Body: body}
+}
+
+// TooManyRequests returns a 429 rate limit response with Retry-After.
+func TooManyRequests(retryAfter time.Duration) Response {
+
+	r := quotaExceeded()
+	r.Header.Set("Retry-After", fmt.Sprint(int(retryAfter.Seconds())))
+
+	return r
+}
+
+// InternalError returns a 500 response with INTERNAL status.
+func InternalError() Response {
+	return Error(http.StatusInternalServerError, "INTERNAL", "backendError", "Internal error encountered.")
+}
+
+// Unavailable returns a 503 response with UNAVAILABLE status.
+func Unavailable() Response {
+	return Error(http.StatusServiceUnavailable, "UNAVAILABLE", "backendError", "The service is currently unavailable.")
+}
+
+// NonJSON returns a response with an HTML body (eg.: the error page of a proxy or a load balancer).
+func NonJSON(status int) Response {
+
+	return Response{
+		Status: status,
+		Header: http.Header{"Content-Type": {"text/html; charset=UTF-8"}},
+		Body:   fmt.Sprintf("<html><head><title>Error %d</title></head><body>%s</body></html>", status, http.StatusText(status)),
+	}
+}
+
+// Truncated returns a successful response that is cut after n bytes.
+func Truncated(n int) Response {
+	return Response{Truncate: n}
+}
+
+// RuntimeError returns a successful response with a runtimeError in the lighthouseResult (eg.: "NO_FCP").
+func RuntimeError(code, message string) Response {
+
+	body := fmt.Sprintf(`{"id":"https://example.com/","lighthouseResult":{"requestedUrl":"https://example.com/","finalUrl":"https://example.com/",`+
+		`"runWarnings":[],"runtimeError":{"code":%q,"message":%q},"audits":{},"categories":{"performance":{"id":"performance","score":null}}}}`, code, message)
+
+	return Response{Header: http.Header{"Content-Type": {"application/json; charset=UTF-8"}}, Body: body}
+}
+
+// quotaExceeded returns the response of an exhausted quota.
+func quotaExceeded() Response {
+
+	msg := "Quota exceeded for quota metric 'Queries' and limit 'Queries per minute' of service 'pagespeedonline.googleapis.com' for consumer 'project_number:000000000000'."
+
+	body := fmt.Sprintf(`{"error":{"code":429,"message":%q,"errors":[{"message":%q,"domain":"global","reason":"rateLimitExceeded"}],"status":"RESOURCE_EXHAUSTED",`+
+		`"details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"RATE_LIMIT_EXCEEDED","domain":"googleapis.com",`+
+		`"metadata":{"quota_limit":"defaultPerMinutePerProject","quota_metric":"pagespeedonline.googleapis.com/default","service":"pagespeedonline.googleapis.com"}}]}}`, msg, msg)
+
+	return Response{Status: http.StatusTooManyRequests, Header: http.Header{"Content-Type": {"application/json; charset=UTF-8"}}, Body: body}
+}
+
+// Server is a fake PageSpeed API server.
+type Server struct {
+	*httptest.Server
+
+	m       sync.Mutex
+	def     Response
+	script  []Response
+	quota   int            // The number of calls allowed per key, unlimited if zero
+	calls   map[string]int // The number of calls per key
+	queries []url.Values   // The query of every request
+}
+
+// NewServer starts a Server, that answers with DefaultBody until a Response is scripted.
+//
+// The server must be closed with Close.
+func NewServer() *Server {
+
+	s := &Server{calls: make(map[string]int)}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// Script appends responses to the script. Every request consumes the first response of the script.
+func (s *Server) Script(r ...Response) {
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.script = append(s.script, r...)
+}
+
+// SetDefault sets the response used if the script is empty.
+func (s *Server) SetDefault(r Response) {
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.def = r
+}
+
+// SetQuota limits the number of calls per API key (the key query parameter) to n.
+// After n calls, the requests with the key are answered with 429 RESOURCE_EXHAUSTED.
+//
+// If n is zero, the calls are not limited.
+func (s *Server) SetQuota(n int) {
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.quota = n
+}
+
+// Calls returns the number of requests received with the API key.
+func (s *Server) Calls(key string) int {
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.calls[key]
+}
+
+// Queries returns the query parameters of the received requests.
+func (s *Server) Queries() []url.Values {
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return append([]url.Values(nil), s.queries...)
+}
+
+// HTTPClient returns an *http.Client that sends every request to the server, regardless of the host of the URL.
+func (s *Server) HTTPClient() *http.Client {
+	return &http.Client{Transport: &transport{s: s}}
+}
+
+// next returns the response to the request.
+func (s *Server) next(r *http.Request) Response {
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	q := r.URL.Query()
+	key := q.Get("key")
+
+	s.queries = append(s.queries, q)
+	s.calls[key]++
+
+	if s.quota > 0 && s.calls[key] > s.quota {
+		return quotaExceeded()
+	}
+
+	if len(s.script) == 0 {
+		return s.def
+	}
+
+	v := s.script[0]
+	s.script = s.script[1:]
+
+	return v
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+
+	v := s.next(r)
+
+	if v.Latency > 0 {
+		select {
+		case <-time.After(v.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if v.Status == 0 {
+		v.Status = http.StatusOK
+	}
+
+	body := v.Body
+	if body == "" && v.Status == http.StatusOK {
+		body = DefaultBody
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	}
+
+	for k := range v.Header {
+		w.Header()[k] = v.Header[k]
+	}
+
+	// The declared length is the full length, so the connection is closed before the end of the body
+	if v.Truncate > 0 && v.Truncate < len(body) {
+		w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+		body = body[:v.Truncate]
+	}
+
+	w.WriteHeader(v.Status)
+
+	_, _ = strings.NewReader(body).WriteTo(w)
+}
+
+// transport rewrites the scheme and the host of the requests to the server.
+type transport struct {
+	s *Server
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	u, err := url.Parse(t.s.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	r := req.Clone(req.Context())
+	r.URL.Scheme = u.Scheme
+	r.URL.Host = u.Host
+	r.Host = u.Host
+
+	return t.s.Client().Transport.RoundTrip(r)
+}
@@ -0,0 +1,201 @@
+package fakeapi_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g0rbe/go-google"
+	"github.com/g0rbe/go-google/internal/fakeapi"
+)
+
+const testURL = "https://www.googleapis.com/pagespeedonline/v5/runPagespeed?url=https%3A%2F%2Fexample.com%2F"
+
+// get sends a request with key to the server.
+func get(t *testing.T, srv *fakeapi.Server, key string) *http.Response {
+
+	u := testURL
+	if key != "" {
+		u += "&key=" + key
+	}
+
+	resp, err := srv.HTTPClient().Get(u)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	t.Cleanup(func() { resp.Body.Close() })
+
+	return resp
+}
+
+func TestServerDefault(t *testing.T) {
+
+	srv := fakeapi.NewServer()
+	defer srv.Close()
+
+	res, err := google.LighthouseResultFromResponse(get(t, srv, ""))
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if res.FinalURL().String() != "https://example.com/" {
+		t.Fatalf("FAIL: invalid FinalURL: %s\n", res.FinalURL())
+	}
+
+	if q := srv.Queries(); len(q) != 1 || q[0].Get("url") != "https://example.com/" {
+		t.Fatalf("FAIL: invalid queries: %v\n", q)
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+
+	cases := []struct {
+		name   string
+		resp   fakeapi.Response
+		status int
+	}{
+		{"429", fakeapi.TooManyRequests(30 * time.Second), 429},
+		{"500", fakeapi.InternalError(), 500},
+		{"503", fakeapi.Unavailable(), 503},
+		{"400", fakeapi.Error(400, "INVALID_ARGUMENT", "invalid", "Invalid value"), 400},
+	}
+
+	srv := fakeapi.NewServer()
+	defer srv.Close()
+
+	for _, tc := range cases {
+
+		srv.Script(tc.resp)
+
+		resp := get(t, srv, "")
+
+		if resp.StatusCode != tc.status {
+			t.Fatalf("FAIL: %s: invalid status: %d\n", tc.name, resp.StatusCode)
+		}
+
+		gerr, err := google.ErrorFromResponse(resp)
+		if err != nil {
+			t.Fatalf("FAIL: %s: %s\n", tc.name, err)
+		}
+
+		if gerr == nil || gerr.Code != tc.status {
+			t.Fatalf("FAIL: %s: invalid error: %#v\n", tc.name, gerr)
+		}
+	}
+
+	// The script is empty, the default response is used
+	if resp := get(t, srv, ""); resp.StatusCode != http.StatusOK {
+		t.Fatalf("FAIL: invalid status after the script: %d\n", resp.StatusCode)
+	}
+}
+
+func TestServerRetryAfter(t *testing.T) {
+
+	srv := fakeapi.NewServer()
+	defer srv.Close()
+
+	srv.Script(fakeapi.TooManyRequests(30 * time.Second))
+
+	if v := get(t, srv, "").Header.Get("Retry-After"); v != "30" {
+		t.Fatalf("FAIL: invalid Retry-After: %q\n", v)
+	}
+}
+
+func TestServerNonJSON(t *testing.T) {
+
+	srv := fakeapi.NewServer()
+	defer srv.Close()
+
+	srv.Script(fakeapi.NonJSON(http.StatusBadGateway))
+
+	resp := get(t, srv, "")
+
+	if resp.StatusCode != http.StatusBadGateway || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Fatalf("FAIL: invalid response: %d %s\n", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+}
+
+func TestServerTruncated(t *testing.T) {
+
+	srv := fakeapi.NewServer()
+	defer srv.Close()
+
+	srv.Script(fakeapi.Truncated(100))
+
+	if _, err := io.ReadAll(get(t, srv, "").Body); err == nil {
+		t.Fatalf("FAIL: no error for truncated body\n")
+	}
+}
+
+func TestServerRuntimeError(t *testing.T) {
+
+	srv := fakeapi.NewServer()
+	defer srv.Close()
+
+	srv.Script(fakeapi.RuntimeError("NO_FCP", "The page did not paint any content."))
+
+	v := struct {
+		LighthouseResult struct {
+			RuntimeError struct {
+				Code string `json:"code"`
+			} `json:"runtimeError"`
+		} `json:"lighthouseResult"`
+	}{}
+
+	if err := json.NewDecoder(get(t, srv, "").Body).Decode(&v); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if v.LighthouseResult.RuntimeError.Code != "NO_FCP" {
+		t.Fatalf("FAIL: invalid runtimeError: %#v\n", v)
+	}
+}
+
+func TestServerLatency(t *testing.T) {
+
+	srv := fakeapi.NewServer()
+	defer srv.Close()
+
+	hc := srv.HTTPClient()
+	hc.Timeout = 50 * time.Millisecond
+
+	srv.Script(fakeapi.Response{Latency: time.Second})
+
+	if _, err := hc.Get(testURL); err == nil {
+		t.Fatalf("FAIL: no error after timeout\n")
+	}
+}
+
+func TestServerQuota(t *testing.T) {
+
+	srv := fakeapi.NewServer()
+	defer srv.Close()
+
+	srv.SetQuota(1)
+
+	if resp := get(t, srv, "key-a"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("FAIL: invalid status of the first call: %d\n", resp.StatusCode)
+	}
+
+	gerr, err := google.ErrorFromResponse(get(t, srv, "key-a"))
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if gerr == nil || gerr.Code != http.StatusTooManyRequests {
+		t.Fatalf("FAIL: invalid error after the quota: %#v\n", gerr)
+	}
+
+	// The quota is per key
+	if resp := get(t, srv, "key-b"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("FAIL: invalid status of other key: %d\n", resp.StatusCode)
+	}
+
+	if srv.Calls("key-a") != 2 || srv.Calls("key-b") != 1 {
+		t.Fatalf("FAIL: invalid number of calls: %d, %d\n", srv.Calls("key-a"), srv.Calls("key-b"))
+	}
+}
@@ -1,12 +1,15 @@
 package google
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -30,76 +33,172 @@ var (
 	LighthouseStrategyMobile  = LighthouseStrategy("mobile")
 )
 
+// Presets for the fields parameter
 var (
+	// Only the category scores
+	LighthouseFieldsScores = LighthouseFields(
+		"id",
+		"lighthouseResult(requestedUrl,finalUrl,fetchTime,runWarnings,runtimeError,timing,categories/*(id,title,score))",
+	)
+
+	// The category scores and the Core Web Vitals (lab and field data)
+	LighthouseFieldsScoresAndVitals = LighthouseFields(
+		"id",
+		"loadingExperience",
+		"originLoadingExperience",
+		"lighthouseResult(requestedUrl,finalUrl,fetchTime,runWarnings,runtimeError,timing,categories/*(id,title,score),"+
+			"audits(first-contentful-paint,largest-contentful-paint,cumulative-layout-shift,total-blocking-time,speed-index,interactive))",
+	)
+
+	// The complete response
+	LighthouseFieldsFull = LighthouseFields("*")
+)
+
+type LighthouseError struct {
+	URL        string            // The requested url
+	Err        error             //
+	Params     []LighthouseParam // The request params, the sensitive values are redacted (eg.: captchaToken, quotaUser)
+	Credential string            // The redacted identifier of the credential (eg.: "...Fk0Q")
+	Attempt    int               // The number of attempts (RunLighthouse sends the request once)
+	Elapsed    time.Duration     // The time elapsed since the start of the run
+	HTTPStatus int               // The status code of the HTTP response, 0 if no response received
+}
+
+func NewLighthouseError(url string, err error) *LighthouseError {
+
+	return &LighthouseError{URL: url, Err: err}
+}
+
+func (e *LighthouseError) Error() string {
+	return fmt.Sprintf("\"%s\": \"%s\"", e.URL, e.Err)
+}
+
+func (e *LighthouseError) Unwrap() error {
+	return e.Err
+}
+
+// lighthouseErrorJSON is the JSON representation of LighthouseError.
+//
+// The type of Err is stored in the field name: *Error in "error" (with the response metadata), *LighthouseRuntimeError in "runtimeError",
+// other errors are stored with the message only.
+type lighthouseErrorJSON struct {
+	URL          string                  `json:"url"`
+	Error        *errorRecord            `json:"error,omitempty"`
+	RuntimeError *LighthouseRuntimeError `json:"runtimeError,omitempty"`
+	Message      string                  `json:"message,omitempty"`
+	Params       []paramJSON             `json:"params,omitempty"`
+	Credential   string                  `json:"credential,omitempty"`
+	Attempt      int                     `json:"attempt,omitempty"`
+	Elapsed      time.Duration           `json:"elapsed,omitempty"` // Nanoseconds
+	HTTPStatus   int                     `json:"httpStatus,omitempty"`
+}
+
+// paramJSON is the JSON representation of Param.
+type paramJSON struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Header bool   `json:"header,omitempty"`
+}
+
+// MarshalJSON implements the [json.Marshaler].
+//
+// The output can be unmarshaled with [LighthouseError.UnmarshalJSON].
+func (e *LighthouseError) MarshalJSON() ([]byte, error) {
+
+	v := lighthouseErrorJSON{URL: e.URL, Credential: e.Credential, Attempt: e.Attempt, Elapsed: e.Elapsed, HTTPStatus: e.HTTPStatus}
 
-	// Invalid domain
-	ErrLighthouseFailedDocumentRequest = &GoogleError{
-		Message: "Lighthouse returned error: FAILED_DOCUMENT_REQUEST. Lighthouse was unable to reliably load the page you requested. Make sure you are testing the correct URL and that the server is properly responding to all requests. (Details: net::ERR_CONNECTION_FAILED)",
-		Domain:  "lighthouse",
-		Reason:  "lighthouseUserError",
+	for i := range e.Params {
+		v.Params = append(v.Params, paramJSON{Key: e.Params[i].k, Value: e.Params[i].v, Header: e.Params[i].header})
 	}
 
-	// Too much request
-	ErrLighthouseUnprocessable = &GoogleError{
-		Message: "Unable to process request. Please wait a while and try again.",
-		Domain:  "global",
-		Reason:  "internalError",
+	switch err := e.Err.(type) {
+	case nil:
+	case *Error:
+		v.Error = &errorRecord{err}
+	case *LighthouseRuntimeError:
+		v.RuntimeError = err
+	default:
+		v.Message = err.Error()
 	}
 
-	ErrLighthouseInvalidKey = &GoogleError{
-		Message: "API key not valid. Please pass a valid API key.",
-		Domain:  "global",
-		Reason:  "badRequest",
+	return json.Marshal(v)
+}
+
+func (e *LighthouseError) UnmarshalJSON(data []byte) error {
+
+	var v lighthouseErrorJSON
+
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
 	}
 
-	ErrLighthouseInvalidCategory = &GoogleError{
-		Message: `^Invalid value at 'category' \(type\.googleapis\.com/google\.chrome\.pagespeedonline\.v5\.PagespeedonlinePagespeedapiRunpagespeedRequest\.Category\), .*$`,
-		Reason:  "invalid",
+	e.URL = v.URL
+	e.Credential = v.Credential
+	e.Attempt = v.Attempt
+	e.Elapsed = v.Elapsed
+	e.HTTPStatus = v.HTTPStatus
+
+	e.Params = nil
+	for i := range v.Params {
+		e.Params = append(e.Params, Param{k: v.Params[i].Key, v: v.Params[i].Value, header: v.Params[i].Header})
 	}
 
-	ErrLighthouseInvalidStrategy = &GoogleError{
-		Message: `^Invalid value at 'strategy' \(type\.googleapis\.com/google\.chrome\.pagespeedonline\.v5\.PagespeedonlinePagespeedapiRunpagespeedRequest\.Strategy\), .*$`,
-		Reason:  "invalid",
+	switch {
+	case v.Error != nil:
+		e.Err = v.Error.err
+	case v.RuntimeError != nil:
+		e.Err = v.RuntimeError
+	case v.Message != "":
+		e.Err = errors.New(v.Message)
+	default:
+		e.Err = nil
 	}
 
-	ErrLighthouseInvalidUrl = &GoogleError{
-		Message:      `^Invalid value '.*'\. Values must match the following regular expression: '\(\?i\)\(url:\|origin:\)\?http\(s\)\?://\.\*'$`,
-		Domain:       "gdata.CoreErrorDomain",
-		Reason:       "INVALID_PARAMETER",
-		Location:     "url",
-		LocationType: "other",
+	return nil
+}
+
+// LogValue implements the [slog.LogValuer].
+func (e *LighthouseError) LogValue() slog.Value {
+
+	attrs := []slog.Attr{slog.String("url", e.URL)}
+
+	if len(e.Params) > 0 {
+
+		params := make([]slog.Attr, 0, len(e.Params))
+
+		for i := range e.Params {
+			params = append(params, slog.String(e.Params[i].k, e.Params[i].v))
+		}
+
+		attrs = append(attrs, slog.Attr{Key: "params", Value: slog.GroupValue(params...)})
 	}
 
-	// Rate limit
-	ErrLighthouseRateLimitExceeded = &GoogleError{
-		Message: `^Quota exceeded for quota metric 'Queries' and limit 'Queries per minute' of service 'pagespeedonline\.googleapis\.com' for consumer '.*'\.$`,
-		Domain:  "global",
-		Reason:  "rateLimitExceeded",
+	if e.Credential != "" {
+		attrs = append(attrs, slog.String("credential", e.Credential))
 	}
-)
 
-type LighthouseError struct {
-	URL string
-	Err error
-}
+	if e.Attempt != 0 {
+		attrs = append(attrs, slog.Int("attempt", e.Attempt))
+	}
 
-func NewLighthouseError(url string, err error) *LighthouseError {
+	if e.Elapsed != 0 {
+		attrs = append(attrs, slog.Duration("elapsed", e.Elapsed))
+	}
 
-	return &LighthouseError{URL: url, Err: err}
-}
+	if e.HTTPStatus != 0 {
+		attrs = append(attrs, slog.Int("httpStatus", e.HTTPStatus))
+	}
 
-func (e *LighthouseError) Error() string {
-	return fmt.Sprintf("\"%s\": \"%s\"", e.URL, e.Err)
-}
+	attrs = append(attrs, slog.Any("error", e.Err))
 
-func (e *LighthouseError) Unwrap() error {
-	return e.Err
+	return slog.GroupValue(attrs...)
 }
 
-// LighthouseParam stores a single request param as a key/value pair.
-type LighthouseParam struct {
-	k, v string
-}
+// LighthouseParam stores a single request param of the PageSpeed API.
+//
+// The system params (eg.: [QuotaUser], [UserProject]) can be used as a LighthouseParam.
+type LighthouseParam = Param
 
 func LighthouseCategory(v string) LighthouseParam {
 	return LighthouseParam{k: "category", v: v}
@@ -125,20 +224,51 @@ func LighthouseCaptchaToken(v string) LighthouseParam {
 	return LighthouseParam{k: "captchaToken", v: v}
 }
 
-func (p LighthouseParam) Key() string {
-	return p.k
+// LighthouseFields sets the fields system parameter to select the parts of the response to include.
+//
+// The fields are joined with a comma, the syntax of a single field is described in
+// https://developers.google.com/speed/docs/insights/performance#partial-response
+func LighthouseFields(fields ...string) LighthouseParam {
+	return LighthouseParam{k: "fields", v: strings.Join(fields, ",")}
 }
 
-func (p LighthouseParam) Value() string {
-	return p.v
+type Audit struct {
+	ID               string             `json:"id,omitempty"`
+	Title            string             `json:"title,omitempty"`
+	Description      string             `json:"description,omitempty"`
+	Score            NullScore          `json:"score"` // Null if the audit has no score (eg.: the audit errored, or informative or manual)
+	ScoreDisplayMode string             `json:"scoreDisplayMode,omitempty"`
+	NumericValue     float64            `json:"numericValue"`           // The value of the metric in NumericUnit
+	NumericUnit      string             `json:"numericUnit,omitempty"`  // eg.: "millisecond", "byte", "unitless"
+	DisplayValue     string             `json:"displayValue,omitempty"` // The formatted value (eg.: "1.2 s")
+	Warnings         []string           `json:"warnings,omitempty"`
+	ErrorMessage     string             `json:"errorMessage,omitempty"` // Set if ScoreDisplayMode is "error"
+	Explanation      string             `json:"explanation,omitempty"`
+	MetricSavings    map[string]float64 `json:"metricSavings,omitempty"` // The estimated savings of the metrics by acronym (eg.: "LCP", "FCP", "CLS")
+	Details          AuditDetails       `json:"details,omitempty"`       // See AuditDetails
 }
 
-type Audit struct {
-	ID               string  `json:"id,omitempty"`
-	Title            string  `json:"title,omitempty"`
-	Description      string  `json:"description,omitempty"`
-	Score            float32 `json:"score"`
-	ScoreDisplayMode string  `json:"scoreDisplayMode,omitempty"`
+func (a *Audit) UnmarshalJSON(data []byte) error {
+
+	type audit Audit
+
+	v := struct {
+		*audit
+		Details json.RawMessage `json:"details"`
+	}{audit: (*audit)(a)}
+
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+
+	a.Details = nil
+
+	if len(v.Details) > 0 && string(v.Details) != "null" {
+		a.Details = unmarshalAuditDetails(v.Details)
+	}
+
+	return nil
 }
 
 type AuditRef struct {
@@ -151,7 +281,7 @@ type Category struct {
 	ID                string     `json:"id,omitempty"`
 	Title             string     `json:"title,omitempty"`
 	Description       string     `json:"description,omitempty"`
-	Score             float32    `json:"score"`
+	Score             NullScore  `json:"score"` // Null if any audit of the category errored
 	ManualDescription string     `json:"manualDescription,omitempty"`
 	AuditRefs         []AuditRef `json:"auditRefs,omitempty"`
 }
@@ -165,17 +295,29 @@ type LighthouseResult struct {
 	requestedUrl   *url.URL                  // The original requested url.
 	finalUrl       *url.URL                  // The final resolved url that was audited.
 	fetchTime      time.Time                 // The time that this run was fetched. (eg.: "2024-07-29T16:25:29.029Z")
-	runWarnings    []error                   //
+	runWarnings    []*RunWarning             // Non-fatal warnings of the run.
 	audits         map[string]*Audit         // An object containing the results of the audits.
 	categories     map[string]*Category      // Map of categories in the LHR.
 	categoryGroups map[string]*CategoryGroup //
 
+	fullPageScreenshot *Screenshot // Screenshot of the full page (decoded only if requested).
+
 	timing time.Duration // The total duration of Lighthouse's run.
+
+	raw json.RawMessage // The original JSON (kept only if requested).
+}
+
+// Screenshot stores an image as a base64 encoded data URL.
+type Screenshot struct {
+	Data   string `json:"data"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
 }
 
 // createLighthouseURL returns the complete URL that can be passed to http.Get().
 //
 // Appends the request parameters to the API endpoint.
+// The header params are not included, use [NewLighthouseRequest] to send them.
 //
 // If any error returned, that comes from Credential cred.
 func CreateLighthouseURL(u string, cred Credential, params ...LighthouseParam) (string, error) {
@@ -197,6 +339,9 @@ func CreateLighthouseURL(u string, cred Credential, params ...LighthouseParam) (
 	}
 
 	for i := range params {
+		if params[i].IsHeader() {
+			continue
+		}
 		uq.Add(params[i].Key(), params[i].Value())
 	}
 
@@ -206,24 +351,37 @@ func CreateLighthouseURL(u string, cred Credential, params ...LighthouseParam) (
 
 }
 
-// LighthouseResultFromResponse reads the LighthouseResult from an *http.Response (eg.: http.Response) and unmarshals it.
-func LighthouseResultFromResponse(r *http.Response) (*LighthouseResult, error) {
+// NewLighthouseRequest returns a GET request to the PageSpeed API with the query params and the header params set.
+//
+// Returns an error if the alt param is not "json", because the package parses JSON responses only.
+// Other errors come from Credential cred or from [http.NewRequestWithContext].
+func NewLighthouseRequest(ctx context.Context, u string, cred Credential, params ...LighthouseParam) (*http.Request, error) {
 
-	data, err := io.ReadAll(r.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read error: %w", err)
+	if err := checkAlt(params...); err != nil {
+		return nil, err
 	}
 
-	v := struct {
-		LighthouseResult *LighthouseResult `json:"lighthouseResult"`
-	}{}
+	getUrl, err := CreateLighthouseURL(u, cred, params...)
+	if err != nil {
+		return nil, err
+	}
 
-	err = json.Unmarshal(data, &v)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getUrl, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return v.LighthouseResult, nil
+	setHeaders(req.Header, params...)
+
+	return req, nil
+}
+
+// LighthouseResultFromResponse decodes the LighthouseResult from the body of an *http.Response.
+//
+// The size of the body is limited to [DefaultMaxResponseSize] and the screenshots are skipped,
+// use [ResponseDecoder] to change it.
+func LighthouseResultFromResponse(r *http.Response) (*LighthouseResult, error) {
+	return NewResponseDecoder(r.Body).DecodeLighthouseResult()
 }
 
 // RunLighthouse runs PageSpeed analysis on the page at the specified URL, and returns Lighthouse scores, a list of suggestions to make that page faster, and other information.
@@ -233,96 +391,111 @@ func LighthouseResultFromResponse(r *http.Response) (*LighthouseResult, error) {
 // The Credential cred must be either *ApiKey or nil.
 //
 // If any error occurs, the returned error is always *LighthouseError.
-// Errors comes from other packages are wrapped in the *LighthouseError (eg.: [http.Get], [json.Unmarshal]).
+// Errors comes from other packages are wrapped in the *LighthouseError (eg.: [http.Client.Do], [json.Unmarshal]).
+// The error of the API is wrapped as *Error, the runtimeError of a failed Lighthouse run is wrapped as *LighthouseRuntimeError
+// (not as *Error, use errors.As with *LighthouseRuntimeError or errors.Is with the ErrLighthouseRuntime errors).
+// See [IsRetryable] and [IsPageError] to classify the errors.
 //
 // API Reference: https://developers.google.com/speed/docs/insights/rest/v5/pagespeedapi/runpagespeed
 func RunLighthouse(u string, cred Credential, params ...LighthouseParam) (*LighthouseResult, error) {
+	return DefaultClient.RunLighthouse(u, cred, params...)
+}
 
-	getUrl, err := CreateLighthouseURL(u, cred, params...)
-	if err != nil {
-		return nil, NewLighthouseError(u, err)
-	}
+// RunLighthouse runs PageSpeed analysis with c.
+//
+// See [RunLighthouse].
+func (c *Client) RunLighthouse(u string, cred Credential, params ...LighthouseParam) (*LighthouseResult, error) {
 
-	resp, err := http.Get(getUrl)
+	r, err := c.RunPageSpeed(u, cred, params...)
 	if err != nil {
-		return nil, NewLighthouseError(u, err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// Error
-	if resp.StatusCode != 200 {
+	return r.LighthouseResult, nil
+}
 
-		gerr, err := ErrorFromResponse(resp)
-		if err != nil {
-			return nil, NewLighthouseError(u, err)
-		}
+// UnmarshalJSON decodes the LHR from data.
+//
+// Unlike [ResponseDecoder], the screenshots are kept, because data is already in memory.
+func (r *LighthouseResult) UnmarshalJSON(data []byte) error {
 
-		return nil, NewLighthouseError(u, gerr)
+	v, err := decodeLighthouseResult(newJSONDecoder(bytes.NewReader(data)), true)
+	if err != nil {
+		return err
 	}
 
-	r, err := LighthouseResultFromResponse(resp)
-	if err != nil {
-		return nil, NewLighthouseError(u, err)
+	if v != nil {
+		*r = *v
 	}
 
-	return r, nil
+	return nil
 }
 
-func (r *LighthouseResult) UnmarshalJSON(data []byte) error {
+// MarshalJSON writes the result in the structure of the LHR, so it can be decoded again with UnmarshalJSON.
+//
+// If the original JSON is kept (see [ResponseDecoder.KeepRaw]), it is returned as is.
+// Otherwise, only the fields stored in LighthouseResult are written, so the output is not a faithful copy of the LHR
+// (eg.: lighthouseVersion, configSettings, environment, i18n and the nodes of fullPageScreenshot are missing).
+// Only KeepRaw gives a faithful copy.
+func (r *LighthouseResult) MarshalJSON() ([]byte, error) {
+
+	if r.raw != nil {
+		return r.raw, nil
+	}
+
+	type screenshot struct {
+		Screenshot *Screenshot `json:"screenshot"`
+	}
 
 	v := struct {
-		RequestedUrl   string                    `json:"requestedUrl"`
-		FinalUrl       string                    `json:"finalUrl"`
-		FetchTime      string                    `json:"fetchTime"`
-		RunWarnings    []string                  `json:"runWarnings"`
-		Audits         map[string]*Audit         `json:"audits"`
-		Categories     map[string]*Category      `json:"categories"`
-		CategoryGroups map[string]*CategoryGroup `json:"categoryGroups"`
-		RuntimeError   *Error                    `json:"runtimeError"`
-		Timing         struct {
+		RequestedUrl       string                    `json:"requestedUrl"`
+		FinalUrl           string                    `json:"finalUrl"`
+		FetchTime          string                    `json:"fetchTime,omitempty"`
+		RunWarnings        []string                  `json:"runWarnings"`
+		Audits             map[string]*Audit         `json:"audits,omitempty"`
+		Categories         map[string]*Category      `json:"categories,omitempty"`
+		CategoryGroups     map[string]*CategoryGroup `json:"categoryGroups,omitempty"`
+		FullPageScreenshot *screenshot               `json:"fullPageScreenshot,omitempty"`
+		Timing             struct {
 			Total float64 `json:"total"`
 		} `json:"timing"`
-	}{}
-
-	err := json.Unmarshal(data, &v)
-	if err != nil {
-		return err
+	}{
+		RunWarnings:    make([]string, 0, len(r.runWarnings)),
+		Audits:         r.audits,
+		Categories:     r.categories,
+		CategoryGroups: r.categoryGroups,
 	}
 
-	for i := range v.RunWarnings {
-		r.runWarnings = append(r.runWarnings, fmt.Errorf(v.RunWarnings[i]))
+	if r.requestedUrl != nil {
+		v.RequestedUrl = r.requestedUrl.String()
 	}
 
-	if v.RuntimeError != nil {
-		v.RuntimeError.Errors = append(v.RuntimeError.Errors, r.runWarnings...)
-
-		return v.RuntimeError
+	if r.finalUrl != nil {
+		v.FinalUrl = r.finalUrl.String()
 	}
 
-	r.requestedUrl, err = url.Parse(v.RequestedUrl)
-	if err != nil {
-		return fmt.Errorf("invalid requestedUrl: %w", err)
+	if !r.fetchTime.IsZero() {
+		v.FetchTime = r.fetchTime.Format(time.RFC3339Nano)
 	}
 
-	r.finalUrl, err = url.Parse(v.FinalUrl)
-	if err != nil {
-		return fmt.Errorf("invalid finalUrl: %w", err)
+	for i := range r.runWarnings {
+		v.RunWarnings = append(v.RunWarnings, r.runWarnings[i].Text)
 	}
 
-	r.fetchTime, err = time.Parse(time.RFC3339, v.FetchTime)
-	if err != nil {
-		return fmt.Errorf("invalid fetchTime: %w", err)
+	if r.fullPageScreenshot != nil {
+		v.FullPageScreenshot = &screenshot{Screenshot: r.fullPageScreenshot}
 	}
 
-	r.audits = v.Audits
-
-	r.categories = v.Categories
+	v.Timing.Total = float64(r.timing) / 1_000_000
 
-	r.categoryGroups = v.CategoryGroups
-
-	r.timing = time.Duration(v.Timing.Total * 1_000_000)
+	return json.Marshal(v)
+}
 
-	return nil
+// Raw returns the original JSON of the result.
+//
+// Returns nil, unless the original JSON is kept (see [ResponseDecoder.KeepRaw]).
+func (r *LighthouseResult) Raw() json.RawMessage {
+	return r.raw
 }
 
 // RequestedURL returns the original requested url.
@@ -341,7 +514,25 @@ func (r *LighthouseResult) FetchTime() time.Time {
 }
 
 // RunWarnings returns warnings (non-fatal errors) coming from the PageSpeed API.
+//
+// The type of the errors is *RunWarning.
 func (r *LighthouseResult) RunWarnings() []error {
+
+	if len(r.runWarnings) == 0 {
+		return nil
+	}
+
+	v := make([]error, 0, len(r.runWarnings))
+
+	for i := range r.runWarnings {
+		v = append(v, r.runWarnings[i])
+	}
+
+	return v
+}
+
+// Warnings returns the categorized run warnings.
+func (r *LighthouseResult) Warnings() []*RunWarning {
 	return r.runWarnings
 }
 
@@ -395,6 +586,27 @@ func (r *LighthouseResult) CategoryGroup(name string) *CategoryGroup {
 	return r.categoryGroups[name]
 }
 
+// GroupAudits returns the audits of the category in the given group (eg.: "load-opportunities"), in the order of the auditRefs.
+//
+// The audits that are referenced, but missing from the result are skipped.
+func (r *LighthouseResult) GroupAudits(category CategoryID, group string) []*Audit {
+
+	c := r.categories[string(category)]
+	if c == nil {
+		return nil
+	}
+
+	var v []*Audit
+
+	for _, ref := range c.AuditRefs {
+		if a := r.audits[ref.ID]; a != nil && ref.Group == group {
+			v = append(v, a)
+		}
+	}
+
+	return v
+}
+
 // CategoryGroups returns the names of the available category groups.
 func (r *LighthouseResult) CategoryGroups() []string {
 
@@ -407,16 +619,27 @@ func (r *LighthouseResult) CategoryGroups() []string {
 	return v
 }
 
+// FullPageScreenshot returns the screenshot of the full page.
+//
+// Returns nil, if the screenshot is missing or not decoded (see [ResponseDecoder.KeepScreenshots]).
+func (r *LighthouseResult) FullPageScreenshot() *Screenshot {
+	return r.fullPageScreenshot
+}
+
 func (r *LighthouseResult) Timing() time.Duration {
 	return r.timing
 }
 
-// Score returns the score of the category.
+// Score returns the score of the category in the range of 0-100, rounded the same way as in the Lighthouse report.
 //
 // If "average" is used as category, returns the average score of the available categories.
 // If "total" is used as category, returns the total score (adds the scores of the available categories).
+// The categories with null score are skipped from the average and counted as 0 in the total.
 //
 // If category not found returns -1.
+//
+// Score returns an int and cannot report a null score, use [LighthouseResult.CategoryScore] and
+// [LighthouseResult.AverageScore] to distinguish the null scores and to average the categories with weights.
 func (r *LighthouseResult) Score(category string) int {
 
 	if len(r.categories) == 0 {
@@ -424,29 +647,25 @@ func (r *LighthouseResult) Score(category string) int {
 	}
 
 	switch category {
-	case "performance", "accessibility", "best-practices", "seo":
-		c := r.Category(category)
-		if c == nil {
-			return -1
-		}
-		return int(c.Score * 100)
-
 	case "average":
-		var s float32
-		for k := range r.categories {
-			s += r.categories[k].Score
-		}
-		return int(s*100) / len(r.categories)
+		s, _ := r.AverageScore(nil).Percent()
+		return s
 
 	case "total":
-		var s float32
+		var s int
 		for k := range r.categories {
-			s += r.categories[k].Score
+			v, _ := r.categories[k].Score.Percent()
+			s += v
 		}
-		return int(s * 100)
+		return s
 
 	default:
-		return -1
+		c := r.Category(category)
+		if c == nil {
+			return -1
+		}
+		s, _ := c.Score.Percent()
+		return s
 	}
 }
 
@@ -454,6 +673,13 @@ func (r *LighthouseResult) Score(category string) int {
 //
 // The Credential and the params are common across RunLighthouse functions.
 func RunConcurrentLighthouse(ctx context.Context, n int, u []string, cred Credential, params ...LighthouseParam) ([]*LighthouseResult, []error) {
+	return DefaultClient.RunConcurrentLighthouse(ctx, n, u, cred, params...)
+}
+
+// RunConcurrentLighthouse use n number of workers to run c.RunLighthouse() concurrently.
+//
+// See [RunConcurrentLighthouse].
+func (c *Client) RunConcurrentLighthouse(ctx context.Context, n int, u []string, cred Credential, params ...LighthouseParam) ([]*LighthouseResult, []error) {
 
 	s := semaphore.NewWeighted(int64(n))
 	m := new(sync.Mutex)
@@ -471,7 +697,7 @@ func RunConcurrentLighthouse(ctx context.Context, n int, u []string, cred Creden
 		go func() {
 			defer s.Release(1)
 
-			if lr, err := RunLighthouse(u[i], cred, params...); err != nil {
+			if lr, err := c.RunLighthouse(u[i], cred, params...); err != nil {
 				m.Lock()
 				e = append(e, err)
 				m.Unlock()
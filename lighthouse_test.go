@@ -1,9 +1,17 @@
 package google_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/g0rbe/go-google"
@@ -143,3 +151,181 @@ func TestRunConcurrentLighthouse(t *testing.T) {
 		}
 	}
 }
+
+func TestLighthouseFields(t *testing.T) {
+
+	p := google.LighthouseFields("id", "lighthouseResult/categories/*/score")
+
+	if p.Key() != "fields" || p.Value() != "id,lighthouseResult/categories/*/score" {
+		t.Fatalf("FAIL: invalid param: %s=%s\n", p.Key(), p.Value())
+	}
+}
+
+func TestLighthouseResultFromResponseTrimmed(t *testing.T) {
+
+	body := `{"id":"https://example.com/","lighthouseResult":{"categories":{"performance":{"id":"performance","score":0.99}}}}`
+
+	resp := &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}
+
+	res, err := google.LighthouseResultFromResponse(resp)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if !res.FetchTime().IsZero() {
+		t.Fatalf("FAIL: FetchTime is not zero: %s\n", res.FetchTime())
+	}
+
+	if res.Score("performance") != 99 {
+		t.Fatalf("FAIL: invalid performance score: %d\n", res.Score("performance"))
+	}
+}
+
+func TestLighthouseResultMarshalJSON(t *testing.T) {
+
+	res := readTestResult(t, true)
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	res1 := new(google.LighthouseResult)
+
+	if err = json.Unmarshal(data, res1); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if res1.FinalURL().String() != res.FinalURL().String() || !res1.FetchTime().Equal(res.FetchTime()) || res1.Timing() != res.Timing() {
+		t.Fatalf("FAIL: invalid result: %s %s %s\n", res1.FinalURL(), res1.FetchTime(), res1.Timing())
+	}
+
+	if len(res1.Audits()) != len(res.Audits()) || len(res1.Warnings()) != len(res.Warnings()) {
+		t.Fatalf("FAIL: invalid number of audits or warnings: %d, %d\n", len(res1.Audits()), len(res1.Warnings()))
+	}
+
+	for _, c := range res.Categories() {
+		if res1.Score(c) != res.Score(c) {
+			t.Fatalf("FAIL: invalid %s score: %d\n", c, res1.Score(c))
+		}
+	}
+
+	for _, id := range res.Audits() {
+		a := res.Audit(id)
+		if a.Details != nil && res1.Audit(id).Details.DetailsType() != a.Details.DetailsType() {
+			t.Fatalf("FAIL: invalid details type of %s: %s\n", id, res1.Audit(id).Details.DetailsType())
+		}
+	}
+
+	// The screenshots must survive the round trip
+	if s := res1.FullPageScreenshot(); s == nil || s.Data == "" || *s != *res.FullPageScreenshot() {
+		t.Fatalf("FAIL: invalid fullPageScreenshot: %#v\n", s)
+	}
+
+	if d, ok := res1.Audit("final-screenshot").Details.(*google.ScreenshotDetails); !ok || d.Data == "" ||
+		d.Data != res.Audit("final-screenshot").Details.(*google.ScreenshotDetails).Data {
+		t.Fatalf("FAIL: invalid final-screenshot: %#v\n", res1.Audit("final-screenshot").Details)
+	}
+
+	if d, ok := res1.Audit("screenshot-thumbnails").Details.(*google.FilmstripDetails); !ok || len(d.Items) == 0 || d.Items[0].Data == "" ||
+		!reflect.DeepEqual(d.Items, res.Audit("screenshot-thumbnails").Details.(*google.FilmstripDetails).Items) {
+		t.Fatalf("FAIL: invalid screenshot-thumbnails: %#v\n", res1.Audit("screenshot-thumbnails").Details)
+	}
+
+	// The result must be the same after the second round trip
+	data1, err := json.Marshal(res1)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	res2 := new(google.LighthouseResult)
+
+	if err = json.Unmarshal(data1, res2); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	data2, err := json.Marshal(res2)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if !bytes.Equal(data1, data2) || !reflect.DeepEqual(res1, res2) {
+		t.Fatalf("FAIL: result changed after round trip\n")
+	}
+}
+
+func TestAuditMarshalJSONZeroNumericValue(t *testing.T) {
+
+	a := new(google.Audit)
+
+	if err := json.Unmarshal([]byte(`{"id":"cumulative-layout-shift","score":1,"scoreDisplayMode":"numeric","numericValue":0,"numericUnit":"unitless"}`), a); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	// A CLS of 0 is a real value
+	if !strings.Contains(string(data), `"numericValue":0`) {
+		t.Fatalf("FAIL: numericValue is dropped: %s\n", data)
+	}
+}
+
+// testTransport sends every request to the test server.
+type testTransport struct {
+	srv *httptest.Server
+}
+
+func (t testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	u, _ := url.Parse(t.srv.URL)
+
+	r := req.Clone(req.Context())
+	r.URL.Scheme = u.Scheme
+	r.URL.Host = u.Host
+
+	return t.srv.Client().Transport.RoundTrip(r)
+}
+
+// newTestClient returns a Client that sends every request to handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *google.Client {
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return google.NewClient(&http.Client{Transport: testTransport{srv: srv}})
+}
+
+func TestClientRunLighthouseError(t *testing.T) {
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"error":{"code":400,"message":"API key not valid. Please pass a valid API key.","errors":[{"message":"API key not valid. Please pass a valid API key.","domain":"global","reason":"badRequest"}],"status":"INVALID_ARGUMENT"}}`)
+	})
+
+	_, err := c.RunLighthouse("https://example.com/", google.NewApiKey("AIzaSyInvalidKey0000Fk0Q"), google.LighthouseStrategyMobile, google.LighthouseCategoryPerformance)
+
+	if !errors.Is(err, google.ErrLighthouseInvalidKey) {
+		t.Fatalf("FAIL: error is not ErrLighthouseInvalidKey: %v\n", err)
+	}
+
+	var lerr *google.LighthouseError
+	if !errors.As(err, &lerr) {
+		t.Fatalf("FAIL: error is not *LighthouseError: %v\n", err)
+	}
+
+	if lerr.URL != "https://example.com/" || lerr.HTTPStatus != 400 || lerr.Attempt != 1 || lerr.Elapsed <= 0 {
+		t.Fatalf("FAIL: invalid *LighthouseError: %#v\n", lerr)
+	}
+
+	if lerr.Credential != "...Fk0Q" {
+		t.Fatalf("FAIL: invalid Credential: %s\n", lerr.Credential)
+	}
+
+	if len(lerr.Params) != 2 || lerr.Params[0].Key() != "strategy" || lerr.Params[1].Value() != "PERFORMANCE" {
+		t.Fatalf("FAIL: invalid Params: %v\n", lerr.Params)
+	}
+}
@@ -0,0 +1,68 @@
+package google
+
+import (
+	"time"
+)
+
+// The ids of the metric audits
+const (
+	AuditFirstContentfulPaint   = "first-contentful-paint"
+	AuditLargestContentfulPaint = "largest-contentful-paint"
+	AuditTotalBlockingTime      = "total-blocking-time"
+	AuditCumulativeLayoutShift  = "cumulative-layout-shift"
+	AuditSpeedIndex             = "speed-index"
+	AuditInteractive            = "interactive"
+)
+
+// TimingMetric is a lab metric measured in time.
+type TimingMetric struct {
+	Value time.Duration
+	Score NullScore // The score of the metric between 0 and 1, null if the audit has no score
+}
+
+// ShiftMetric is a unitless lab metric (eg.: Cumulative Layout Shift).
+type ShiftMetric struct {
+	Value float64
+	Score NullScore // The score of the metric between 0 and 1, null if the audit has no score
+}
+
+// LabMetrics stores the Core Web Vitals and the timing metrics measured by Lighthouse.
+//
+// The metric is nil if the audit is missing or has no numeric value (eg.: the audit errored).
+type LabMetrics struct {
+	FirstContentfulPaint   *TimingMetric // FCP
+	LargestContentfulPaint *TimingMetric // LCP
+	TotalBlockingTime      *TimingMetric // TBT
+	CumulativeLayoutShift  *ShiftMetric  // CLS
+	SpeedIndex             *TimingMetric // SI
+	TimeToInteractive      *TimingMetric // TTI
+}
+
+// timingMetric returns the metric from the audit with the given id.
+func (r *LighthouseResult) timingMetric(id string) *TimingMetric {
+
+	a := r.Audit(id)
+	if a == nil || a.NumericUnit != "millisecond" {
+		return nil
+	}
+
+	return &TimingMetric{Value: time.Duration(a.NumericValue * float64(time.Millisecond)), Score: a.Score}
+}
+
+// LabMetrics returns the lab metrics from the audits.
+func (r *LighthouseResult) LabMetrics() *LabMetrics {
+
+	v := &LabMetrics{
+		FirstContentfulPaint:   r.timingMetric(AuditFirstContentfulPaint),
+		LargestContentfulPaint: r.timingMetric(AuditLargestContentfulPaint),
+		TotalBlockingTime:      r.timingMetric(AuditTotalBlockingTime),
+		SpeedIndex:             r.timingMetric(AuditSpeedIndex),
+		TimeToInteractive:      r.timingMetric(AuditInteractive),
+	}
+
+	if a := r.Audit(AuditCumulativeLayoutShift); a != nil && a.NumericUnit == "unitless" {
+		v.CumulativeLayoutShift = &ShiftMetric{Value: a.NumericValue, Score: a.Score}
+	}
+
+	return v
+}
@@ -0,0 +1,27 @@
+package google_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLabMetrics(t *testing.T) {
+
+	m := readTestResult(t, false).LabMetrics()
+
+	if m.LargestContentfulPaint == nil || m.LargestContentfulPaint.Value != 2612300*time.Microsecond || !m.LargestContentfulPaint.Score.Valid || m.LargestContentfulPaint.Score.Value < 0.759 || m.LargestContentfulPaint.Score.Value > 0.761 {
+		t.Fatalf("FAIL: invalid LCP: %#v\n", m.LargestContentfulPaint)
+	}
+
+	if m.CumulativeLayoutShift == nil || m.CumulativeLayoutShift.Value != 0.091 {
+		t.Fatalf("FAIL: invalid CLS: %#v\n", m.CumulativeLayoutShift)
+	}
+
+	if m.TotalBlockingTime == nil || m.TotalBlockingTime.Value != 45*time.Millisecond {
+		t.Fatalf("FAIL: invalid TBT: %#v\n", m.TotalBlockingTime)
+	}
+
+	if m.FirstContentfulPaint == nil || m.SpeedIndex == nil || m.TimeToInteractive == nil {
+		t.Fatalf("FAIL: missing metric: %#v\n", m)
+	}
+}
@@ -0,0 +1,172 @@
+package google
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// The keys of the field metrics in LoadingExperience.Metrics
+const (
+	FieldLargestContentfulPaint = "LARGEST_CONTENTFUL_PAINT_MS"
+	FieldInteractionToNextPaint = "INTERACTION_TO_NEXT_PAINT"
+	FieldCumulativeLayoutShift  = "CUMULATIVE_LAYOUT_SHIFT_SCORE" // The percentile is multiplied by 100 (eg.: 5 means 0.05)
+	FieldFirstContentfulPaint   = "FIRST_CONTENTFUL_PAINT_MS"
+	FieldTimeToFirstByte        = "EXPERIMENTAL_TIME_TO_FIRST_BYTE"
+	FieldFirstInputDelay        = "FIRST_INPUT_DELAY_MS" // Deprecated by Google, replaced by INP
+)
+
+// Possible values of the category of the field data
+const (
+	FieldCategoryFast    = "FAST"    // Good
+	FieldCategoryAverage = "AVERAGE" // Needs improvement
+	FieldCategorySlow    = "SLOW"    // Poor
+	FieldCategoryNone    = "NONE"    // Not enough data
+)
+
+// Distribution is the proportion of the page loads in a range of the metric values.
+type Distribution struct {
+	Min        float64 `json:"min"`
+	Max        float64 `json:"max,omitempty"` // Zero in the last range, which has no upper bound
+	Proportion float64 `json:"proportion"`
+}
+
+// FieldMetric is a real-user metric from the Chrome User Experience Report.
+type FieldMetric struct {
+	Percentile    float64        `json:"percentile"` // The 75th percentile of the metric
+	Distributions []Distribution `json:"distributions"`
+	Category      string         `json:"category"` // See FieldCategory*
+}
+
+// LoadingExperience stores the field data of a page or an origin.
+type LoadingExperience struct {
+	ID              string                  `json:"id"`
+	InitialURL      string                  `json:"initial_url,omitempty"`
+	Metrics         map[string]*FieldMetric `json:"metrics"`
+	OverallCategory string                  `json:"overall_category"`          // See FieldCategory*
+	OriginFallback  bool                    `json:"origin_fallback,omitempty"` // The page has not enough data, the origin data is used
+}
+
+// PageSpeedResponse is the response of the PageSpeed API.
+type PageSpeedResponse struct {
+	ID                      string             // The canonicalized and final URL
+	AnalysisTime            time.Time          // The time of the analysis
+	LighthouseResult        *LighthouseResult  // Lab data
+	LoadingExperience       *LoadingExperience // Field data of the page
+	OriginLoadingExperience *LoadingExperience // Field data of the origin
+}
+
+// PageSpeedResponseFromResponse decodes the PageSpeedResponse from the body of an *http.Response.
+//
+// The size of the body is limited to [DefaultMaxResponseSize] and the screenshots are skipped,
+// use [ResponseDecoder] to change it.
+func PageSpeedResponseFromResponse(r *http.Response) (*PageSpeedResponse, error) {
+	return NewResponseDecoder(r.Body).DecodePageSpeedResponse()
+}
+
+// RunPageSpeed runs PageSpeed analysis on the page at the specified URL, and returns the lab data (LighthouseResult) and the field data.
+//
+// The parameters are the same as in [RunLighthouse].
+// If any error occurs, the returned error is always *LighthouseError.
+func RunPageSpeed(u string, cred Credential, params ...LighthouseParam) (*PageSpeedResponse, error) {
+	return DefaultClient.RunPageSpeed(u, cred, params...)
+}
+
+// RunPageSpeed runs PageSpeed analysis with c.
+//
+// See [RunPageSpeed].
+func (c *Client) RunPageSpeed(u string, cred Credential, params ...LighthouseParam) (*PageSpeedResponse, error) {
+
+	lerr := &LighthouseError{URL: u, Params: redactParams(params), Attempt: 1}
+
+	start := time.Now()
+
+	fail := func(err error) error {
+		lerr.Err = err
+		lerr.Elapsed = time.Since(start)
+		return lerr
+	}
+
+	req, err := NewLighthouseRequest(context.Background(), u, cred, params...)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	lerr.Credential = redactToken(req.URL.Query().Get("key"))
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fail(err)
+	}
+	defer resp.Body.Close()
+
+	lerr.HTTPStatus = resp.StatusCode
+
+	// Error
+	if resp.StatusCode != 200 {
+
+		gerr, err := c.NewDecoder(resp.Body).DecodeErrorResponse(resp)
+		if err != nil {
+			return nil, fail(err)
+		}
+
+		return nil, fail(gerr)
+	}
+
+	r, err := c.NewDecoder(resp.Body).DecodePageSpeedResponse()
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return r, nil
+}
+
+// Good returns the proportion of the page loads in the good range.
+func (m *FieldMetric) Good() float64 {
+	return m.proportion(0)
+}
+
+// NeedsImprovement returns the proportion of the page loads in the needs improvement range.
+func (m *FieldMetric) NeedsImprovement() float64 {
+	return m.proportion(1)
+}
+
+// Poor returns the proportion of the page loads in the poor range.
+func (m *FieldMetric) Poor() float64 {
+	return m.proportion(2)
+}
+
+func (m *FieldMetric) proportion(i int) float64 {
+
+	if i >= len(m.Distributions) {
+		return 0
+	}
+
+	return m.Distributions[i].Proportion
+}
+
+// Metric returns the field metric with the given key (eg.: FieldLargestContentfulPaint).
+//
+// If metric not found, returns nil.
+func (e *LoadingExperience) Metric(key string) *FieldMetric {
+
+	if e == nil {
+		return nil
+	}
+
+	return e.Metrics[key]
+}
+
+// HasFieldData returns whether the page or the origin has field data.
+func (r *PageSpeedResponse) HasFieldData() bool {
+	return len(r.LoadingExperience.metrics()) > 0 || len(r.OriginLoadingExperience.metrics()) > 0
+}
+
+func (e *LoadingExperience) metrics() map[string]*FieldMetric {
+
+	if e == nil {
+		return nil
+	}
+
+	return e.Metrics
+}
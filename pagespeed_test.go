@@ -0,0 +1,64 @@
+package google_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+func TestRunPageSpeed(t *testing.T) {
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, "testdata/pagespeed.json")
+	})
+
+	res, err := c.RunPageSpeed("https://example.com/", nil, google.LighthouseCategoryAll...)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if res.ID != "https://example.com/" || res.AnalysisTime.IsZero() {
+		t.Fatalf("FAIL: invalid response: %#v\n", res)
+	}
+
+	if res.LighthouseResult == nil || res.LighthouseResult.RequestedURL().String() != "https://example.com/" {
+		t.Fatalf("FAIL: invalid LighthouseResult: %#v\n", res.LighthouseResult)
+	}
+
+	if !res.HasFieldData() {
+		t.Fatalf("FAIL: no field data\n")
+	}
+
+	le := res.LoadingExperience
+
+	if le.OverallCategory != google.FieldCategoryFast || le.InitialURL != "https://example.com/" {
+		t.Fatalf("FAIL: invalid loadingExperience: %#v\n", le)
+	}
+
+	lcp := le.Metric(google.FieldLargestContentfulPaint)
+	if lcp == nil || lcp.Percentile != 2300 || lcp.Category != google.FieldCategoryFast {
+		t.Fatalf("FAIL: invalid LCP: %#v\n", lcp)
+	}
+
+	if lcp.Good() != 0.8 || lcp.NeedsImprovement() != 0.12 || lcp.Poor() != 0.08 {
+		t.Fatalf("FAIL: invalid LCP distributions: %#v\n", lcp.Distributions)
+	}
+
+	if cls := res.OriginLoadingExperience.Metric(google.FieldCumulativeLayoutShift); cls == nil || cls.Percentile != 12 || cls.Category != google.FieldCategoryAverage {
+		t.Fatalf("FAIL: invalid origin CLS: %#v\n", cls)
+	}
+}
+
+func TestDecodePageSpeedResponseNoFieldData(t *testing.T) {
+
+	res, err := google.NewResponseDecoder(strings.NewReader(`{"id":"https://example.com/","loadingExperience":{"initial_url":"https://example.com/"}}`)).DecodePageSpeedResponse()
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if res.HasFieldData() || res.LighthouseResult != nil || res.LoadingExperience.Metric(google.FieldLargestContentfulPaint) != nil {
+		t.Fatalf("FAIL: invalid response: %#v\n", res)
+	}
+}
@@ -0,0 +1,108 @@
+package google
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+)
+
+// Param stores a single request param as a key/value pair.
+//
+// A Param is either a query parameter or an HTTP header (see [Param.IsHeader]).
+type Param struct {
+	k, v   string
+	header bool
+}
+
+// AltJSON is the default value of the alt parameter, the only data format supported by the package.
+var AltJSON = Alt("json")
+
+// PrettyPrint returns the prettyPrint system parameter.
+//
+// Use PrettyPrint(false) to remove the indentations and line breaks from the response.
+func PrettyPrint(v bool) Param {
+	return Param{k: "prettyPrint", v: strconv.FormatBool(v)}
+}
+
+// QuotaUser returns the quotaUser system parameter.
+//
+// The quota is attributed to the given user (eg.: the end user of a SaaS) instead of the caller.
+func QuotaUser(v string) Param {
+	return Param{k: "quotaUser", v: v}
+}
+
+// UserIP returns the legacy userIp system parameter.
+//
+// Prefer [QuotaUser].
+func UserIP(v string) Param {
+	return Param{k: "userIp", v: v}
+}
+
+// Alt returns the alt system parameter that sets the data format of the response.
+//
+// The package parses JSON responses only, [NewLighthouseRequest] rejects the other formats.
+func Alt(v string) Param {
+	return Param{k: "alt", v: v}
+}
+
+// UserProject returns the X-Goog-User-Project header.
+//
+// The project is used for quota and billing.
+func UserProject(v string) Param {
+	return Param{k: "X-Goog-User-Project", v: v, header: true}
+}
+
+// ApiClient returns the X-Goog-Api-Client header that identifies the client library.
+func ApiClient(v string) Param {
+	return Param{k: "X-Goog-Api-Client", v: v, header: true}
+}
+
+func (p Param) Key() string {
+	return p.k
+}
+
+func (p Param) Value() string {
+	return p.v
+}
+
+// IsHeader returns whether p is sent as an HTTP header instead of a query parameter.
+func (p Param) IsHeader() bool {
+	return p.header
+}
+
+// checkAlt returns an error if the alt param of params is not JSON.
+func checkAlt(params ...Param) error {
+
+	for i := range params {
+		if !params[i].header && params[i].k == "alt" && params[i].v != "json" {
+			return fmt.Errorf("unsupported alt: %q, only \"json\" is supported", params[i].v)
+		}
+	}
+
+	return nil
+}
+
+// setHeaders adds the header params to h.
+func setHeaders(h http.Header, params ...Param) {
+
+	for i := range params {
+		if params[i].header {
+			h.Add(params[i].k, params[i].v)
+		}
+	}
+}
+
+// redactParams returns a copy of params with the values of the sensitive params redacted (see [redactToken]).
+func redactParams(params []Param) []Param {
+
+	v := append([]Param(nil), params...)
+
+	for i := range v {
+		if !v[i].header && slices.Contains(redactedParams, v[i].k) {
+			v[i].v = redactToken(v[i].v)
+		}
+	}
+
+	return v
+}
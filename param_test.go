@@ -0,0 +1,54 @@
+package google_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+func TestNewLighthouseRequestSystemParams(t *testing.T) {
+
+	req, err := google.NewLighthouseRequest(context.TODO(), "https://example.com/", google.NewApiKey("key"),
+		google.LighthouseCategoryPerformance,
+		google.PrettyPrint(false),
+		google.QuotaUser("user-1"),
+		google.UserProject("project-1"),
+		google.ApiClient("go-google/test"))
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	q := req.URL.Query()
+
+	if q.Get("prettyPrint") != "false" {
+		t.Fatalf("FAIL: invalid prettyPrint: %s\n", q.Get("prettyPrint"))
+	}
+
+	if q.Get("quotaUser") != "user-1" {
+		t.Fatalf("FAIL: invalid quotaUser: %s\n", q.Get("quotaUser"))
+	}
+
+	if q.Has("X-Goog-User-Project") {
+		t.Fatalf("FAIL: header param in the query\n")
+	}
+
+	if req.Header.Get("X-Goog-User-Project") != "project-1" {
+		t.Fatalf("FAIL: invalid X-Goog-User-Project: %s\n", req.Header.Get("X-Goog-User-Project"))
+	}
+
+	if req.Header.Get("X-Goog-Api-Client") != "go-google/test" {
+		t.Fatalf("FAIL: invalid X-Goog-Api-Client: %s\n", req.Header.Get("X-Goog-Api-Client"))
+	}
+}
+
+func TestNewLighthouseRequestAlt(t *testing.T) {
+
+	if _, err := google.NewLighthouseRequest(context.TODO(), "https://example.com/", nil, google.AltJSON); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if _, err := google.NewLighthouseRequest(context.TODO(), "https://example.com/", nil, google.Alt("proto")); err == nil {
+		t.Fatalf("FAIL: alt=proto is accepted\n")
+	}
+}
@@ -0,0 +1,138 @@
+package google
+
+import (
+	"sort"
+)
+
+// The groups of the performance audits
+const (
+	GroupMetrics           = "metrics"
+	GroupLoadOpportunities = "load-opportunities"
+	GroupDiagnostics       = "diagnostics"
+)
+
+// passThreshold is the minimum score of a passed audit, the same as in the Lighthouse report.
+const passThreshold = 0.9
+
+// Opportunity is a performance audit with the estimated savings.
+type Opportunity struct {
+	Audit        *Audit  `json:"audit"`
+	SavingsMs    float64 `json:"savingsMs"`    // The estimated savings of the load time in milliseconds
+	SavingsBytes float64 `json:"savingsBytes"` // The estimated savings of the transfer size in bytes
+}
+
+// auditPassed returns whether the audit is passed, not applicable or manual.
+func auditPassed(a *Audit) bool {
+
+	switch a.ScoreDisplayMode {
+	case "notApplicable", "manual":
+		return true
+	case "informative", "error":
+		return false
+	}
+
+	s := a.Score
+
+	return s.Valid && s.Value >= passThreshold
+}
+
+// auditSavings returns the estimated savings from the details of the audit.
+//
+// If the details have no time savings, the larger of the LCP and FCP savings in metricSavings is used.
+func auditSavings(a *Audit) (ms float64, bytes float64) {
+
+	switch d := a.Details.(type) {
+	case *OpportunityDetails:
+		ms, bytes = d.OverallSavingsMs, d.OverallSavingsBytes
+	case *TableDetails:
+		if d.Summary != nil {
+			ms, bytes = d.Summary.WastedMs, d.Summary.WastedBytes
+		}
+	}
+
+	if ms == 0 {
+		ms = max(a.MetricSavings["LCP"], a.MetricSavings["FCP"])
+	}
+
+	return ms, bytes
+}
+
+// opportunityAudits returns the audits of the load-opportunities group.
+//
+// Lighthouse 11+ may merge the load-opportunities group into the diagnostics group.
+// If the load-opportunities group has no audits, returns the audits of the diagnostics group
+// with OpportunityDetails or metricSavings.
+func (r *LighthouseResult) opportunityAudits() []*Audit {
+
+	v := r.GroupAudits(CategoryPerformance, GroupLoadOpportunities)
+	if len(v) > 0 {
+		return v
+	}
+
+	for _, a := range r.GroupAudits(CategoryPerformance, GroupDiagnostics) {
+		if _, ok := a.Details.(*OpportunityDetails); ok || len(a.MetricSavings) > 0 {
+			v = append(v, a)
+		}
+	}
+
+	return v
+}
+
+// Opportunities returns the failed audits of the load-opportunities group with estimated savings.
+// If the group is missing (Lighthouse 11+), the opportunities are selected from the diagnostics group.
+//
+// The opportunities are sorted by impact: by SavingsMs and then by SavingsBytes, the largest is the first.
+func (r *LighthouseResult) Opportunities() []*Opportunity {
+
+	var v []*Opportunity
+
+	for _, a := range r.opportunityAudits() {
+
+		if auditPassed(a) {
+			continue
+		}
+
+		o := &Opportunity{Audit: a}
+		o.SavingsMs, o.SavingsBytes = auditSavings(a)
+
+		if o.SavingsMs > 0 || o.SavingsBytes > 0 {
+			v = append(v, o)
+		}
+	}
+
+	sort.SliceStable(v, func(i, j int) bool {
+		if v[i].SavingsMs != v[j].SavingsMs {
+			return v[i].SavingsMs > v[j].SavingsMs
+		}
+		return v[i].SavingsBytes > v[j].SavingsBytes
+	})
+
+	return v
+}
+
+// Diagnostics returns the failed and the informative audits of the diagnostics group.
+//
+// The failed audits are sorted by score (the lowest is the first) and followed by the informative audits.
+func (r *LighthouseResult) Diagnostics() []*Audit {
+
+	var v []*Audit
+
+	for _, a := range r.GroupAudits(CategoryPerformance, GroupDiagnostics) {
+		if !auditPassed(a) {
+			v = append(v, a)
+		}
+	}
+
+	sort.SliceStable(v, func(i, j int) bool {
+
+		si, sj := v[i].Score, v[j].Score
+
+		if ii, ij := v[i].ScoreDisplayMode == "informative", v[j].ScoreDisplayMode == "informative"; ii != ij {
+			return ij
+		}
+
+		return si.Valid && (!sj.Valid || si.Value < sj.Value)
+	})
+
+	return v
+}
@@ -0,0 +1,111 @@
+package google_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+func TestOpportunities(t *testing.T) {
+
+	v := readTestResult(t, false).Opportunities()
+
+	if len(v) != 2 {
+		t.Fatalf("FAIL: invalid number of opportunities: %d\n", len(v))
+	}
+
+	if v[0].Audit.ID != "render-blocking-resources" || v[0].SavingsMs != 630 {
+		t.Fatalf("FAIL: invalid first opportunity: %s %v\n", v[0].Audit.ID, v[0].SavingsMs)
+	}
+
+	if v[1].Audit.ID != "unused-javascript" || v[1].SavingsMs != 300 || v[1].SavingsBytes != 122880 {
+		t.Fatalf("FAIL: invalid second opportunity: %s %v %v\n", v[1].Audit.ID, v[1].SavingsMs, v[1].SavingsBytes)
+	}
+}
+
+func TestOpportunitiesMergedGroup(t *testing.T) {
+
+	// Lighthouse 11+ style result without the load-opportunities group
+	data := `{"lighthouseResult":{"audits":{` +
+		`"render-blocking-resources":{"id":"render-blocking-resources","score":0.5,"scoreDisplayMode":"metricSavings","metricSavings":{"LCP":450,"FCP":300},` +
+		`"details":{"type":"table","headings":[],"items":[]}},` +
+		`"unused-javascript":{"id":"unused-javascript","score":0,"scoreDisplayMode":"metricSavings","metricSavings":{"LCP":0},` +
+		`"details":{"type":"opportunity","headings":[],"items":[],"overallSavingsMs":0,"overallSavingsBytes":122880}},` +
+		`"layout-shifts":{"id":"layout-shifts","score":0,"scoreDisplayMode":"metricSavings","metricSavings":{"CLS":0.2}},` +
+		`"dom-size":{"id":"dom-size","score":0.5,"scoreDisplayMode":"numeric","numericValue":1200}},` +
+		`"categories":{"performance":{"id":"performance","score":0.5,"auditRefs":[` +
+		`{"id":"render-blocking-resources","weight":0,"group":"diagnostics"},` +
+		`{"id":"unused-javascript","weight":0,"group":"diagnostics"},` +
+		`{"id":"layout-shifts","weight":0,"group":"diagnostics"},` +
+		`{"id":"dom-size","weight":0,"group":"diagnostics"}]}}}}`
+
+	res, err := google.NewResponseDecoder(strings.NewReader(data)).DecodeLighthouseResult()
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	v := res.Opportunities()
+
+	if len(v) != 2 {
+		t.Fatalf("FAIL: invalid number of opportunities: %d\n", len(v))
+	}
+
+	if v[0].Audit.ID != "render-blocking-resources" || v[0].SavingsMs != 450 || v[0].Audit.MetricSavings["FCP"] != 300 {
+		t.Fatalf("FAIL: invalid first opportunity: %s %v\n", v[0].Audit.ID, v[0].SavingsMs)
+	}
+
+	if v[1].Audit.ID != "unused-javascript" || v[1].SavingsMs != 0 || v[1].SavingsBytes != 122880 {
+		t.Fatalf("FAIL: invalid second opportunity: %s %v %v\n", v[1].Audit.ID, v[1].SavingsMs, v[1].SavingsBytes)
+	}
+}
+
+func TestOpportunityMarshalJSON(t *testing.T) {
+
+	v := readTestResult(t, false).Opportunities()
+
+	data, err := json.Marshal(v[1])
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	o := struct {
+		Audit struct {
+			ID string `json:"id"`
+		} `json:"audit"`
+		SavingsMs    float64 `json:"savingsMs"`
+		SavingsBytes float64 `json:"savingsBytes"`
+	}{}
+
+	if err = json.Unmarshal(data, &o); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if o.Audit.ID != "unused-javascript" || o.SavingsMs != 300 || o.SavingsBytes != 122880 {
+		t.Fatalf("FAIL: invalid JSON: %s\n", data)
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+
+	v := readTestResult(t, false).Diagnostics()
+
+	if len(v) != 2 || v[0].ID != "mainthread-work-breakdown" || v[1].ID != "dom-size" {
+		t.Fatalf("FAIL: invalid diagnostics: %v\n", v)
+	}
+}
+
+func TestGroupAudits(t *testing.T) {
+
+	res := readTestResult(t, false)
+
+	v := res.GroupAudits(google.CategoryPerformance, google.GroupMetrics)
+	if len(v) != 5 || v[0].ID != "first-contentful-paint" {
+		t.Fatalf("FAIL: invalid metrics: %v\n", v)
+	}
+
+	if res.GroupAudits("pwa", google.GroupMetrics) != nil {
+		t.Fatalf("FAIL: audits of missing category\n")
+	}
+}
@@ -0,0 +1,96 @@
+package google
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrUnknownReport returned if the format of a report is not recognized.
+var ErrUnknownReport = errors.New("unknown report format")
+
+// DecodeReport decodes a LighthouseResult from any supported report format:
+//
+//   - a plain LHR (eg.: the output of the Lighthouse CLI with "--output json"),
+//   - a PageSpeed API response (the lighthouseResult field),
+//   - a Lighthouse CI run upload payload (the lhr field, either as an object or as a JSON encoded string).
+//
+// Unlike the other decode methods, the whole report is read into memory to detect the format.
+func (d *ResponseDecoder) DecodeReport() (*LighthouseResult, error) {
+
+	data, err := io.ReadAll(d.reader())
+	if err != nil {
+		return nil, fmt.Errorf("read report: %w", err)
+	}
+
+	var v map[string]json.RawMessage
+
+	err = json.Unmarshal(data, &v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report: %w", err)
+	}
+
+	switch {
+	case v["lighthouseResult"] != nil:
+		data = v["lighthouseResult"]
+	case v["lhr"] != nil:
+		data = v["lhr"]
+		// Lighthouse CI stores the LHR as a string
+		if len(data) > 0 && data[0] == '"' {
+			var s string
+			if err = json.Unmarshal(data, &s); err != nil {
+				return nil, fmt.Errorf("invalid lhr: %w", err)
+			}
+			data = []byte(s)
+		}
+	case v["lighthouseVersion"] != nil, v["audits"] != nil, v["categories"] != nil:
+		// Plain LHR
+	default:
+		return nil, ErrUnknownReport
+	}
+
+	r, err := d.decodeResult(newJSONDecoder(bytes.NewReader(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	if r == nil {
+		return nil, ErrUnknownReport
+	}
+
+	return r, nil
+}
+
+// LoadLighthouseResult reads a report from r and returns the LighthouseResult.
+//
+// See [ResponseDecoder.DecodeReport] for the supported formats.
+// The screenshots are kept (see [ResponseDecoder.KeepScreenshots]).
+func LoadLighthouseResult(r io.Reader) (*LighthouseResult, error) {
+
+	d := NewResponseDecoder(r)
+	d.KeepScreenshots()
+
+	return d.DecodeReport()
+}
+
+// LoadLighthouseResultFile reads the report in file name and returns the LighthouseResult.
+//
+// See [ResponseDecoder.DecodeReport] for the supported formats.
+func LoadLighthouseResultFile(name string) (*LighthouseResult, error) {
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := LoadLighthouseResult(f)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", name, err)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,107 @@
+package google_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+func TestLoadLighthouseResult(t *testing.T) {
+
+	data, err := os.ReadFile("testdata/pagespeed.json")
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	v := struct {
+		LighthouseResult json.RawMessage `json:"lighthouseResult"`
+	}{}
+
+	if err = json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	lhciString, err := json.Marshal(map[string]any{"url": "https://example.com/", "representative": true, "lhr": string(v.LighthouseResult)})
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	lhciObject, err := json.Marshal(map[string]any{"url": "https://example.com/", "lhr": v.LighthouseResult})
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	reports := map[string][]byte{
+		"pagespeed":   data,
+		"lhr":         v.LighthouseResult,
+		"lhci-string": lhciString,
+		"lhci-object": lhciObject,
+	}
+
+	want := readTestResult(t, false)
+
+	for name, report := range reports {
+
+		res, err := google.LoadLighthouseResult(bytes.NewReader(report))
+		if err != nil {
+			t.Fatalf("FAIL: %s: %s\n", name, err)
+		}
+
+		if res.FinalURL().String() != want.FinalURL().String() || !res.FetchTime().Equal(want.FetchTime()) {
+			t.Fatalf("FAIL: %s: invalid result: %s %s\n", name, res.FinalURL(), res.FetchTime())
+		}
+
+		if len(res.Audits()) != len(want.Audits()) || res.Score("performance") != want.Score("performance") {
+			t.Fatalf("FAIL: %s: invalid audits or score: %d, %d\n", name, len(res.Audits()), res.Score("performance"))
+		}
+
+		if s := res.FullPageScreenshot(); s == nil || s.Data == "" {
+			t.Fatalf("FAIL: %s: fullPageScreenshot is not kept\n", name)
+		}
+	}
+}
+
+func TestLoadLighthouseResultFile(t *testing.T) {
+
+	name := filepath.Join(t.TempDir(), "lhr.json")
+
+	err := os.WriteFile(name, []byte(`{"lighthouseVersion":"12.0.0","requestedUrl":"https://example.com/","finalUrl":"https://example.com/","categories":{"seo":{"id":"seo","score":0.5}}}`), 0o600)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	res, err := google.LoadLighthouseResultFile(name)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if res.Score("seo") != 50 {
+		t.Fatalf("FAIL: invalid seo score: %d\n", res.Score("seo"))
+	}
+}
+
+func TestLoadLighthouseResultUnknown(t *testing.T) {
+
+	for _, report := range []string{`{"id":"https://example.com/"}`, `{"lighthouseResult":null}`} {
+
+		_, err := google.LoadLighthouseResult(bytes.NewReader([]byte(report)))
+		if !errors.Is(err, google.ErrUnknownReport) {
+			t.Fatalf("FAIL: unexpected error for %s: %v\n", report, err)
+		}
+	}
+}
+
+func TestLoadLighthouseResultRuntimeError(t *testing.T) {
+
+	report := `{"lighthouseVersion":"12.0.0","runtimeError":{"code":"NO_FCP","message":"The page did not paint any content."}}`
+
+	_, err := google.LoadLighthouseResult(bytes.NewReader([]byte(report)))
+	if !errors.Is(err, google.ErrLighthouseRuntimeNoFCP) {
+		t.Fatalf("FAIL: unexpected error: %v\n", err)
+	}
+}
@@ -0,0 +1,102 @@
+package google
+
+import (
+	"regexp"
+)
+
+// LighthouseRuntimeError is a fatal error of the Lighthouse run.
+//
+// The error is reported either in the runtimeError field of the LighthouseResult or
+// in the message of a *GoogleError (eg.: "Lighthouse returned error: NO_FCP. ...").
+//
+// See: https://github.com/GoogleChrome/lighthouse/blob/main/core/lib/lh-error.js
+type LighthouseRuntimeError struct {
+	Code     string  `json:"code"`
+	Message  string  `json:"message"`
+	Warnings []error `json:"-"` // The run warnings of the failed run
+}
+
+// The Lighthouse runtime errors, matched by the Code only.
+var (
+
+	// The page cannot be loaded (eg.: the site is down)
+	ErrLighthouseRuntimeDNSFailure              = &LighthouseRuntimeError{Code: "DNS_FAILURE"}
+	ErrLighthouseRuntimeFailedDocumentRequest   = &LighthouseRuntimeError{Code: "FAILED_DOCUMENT_REQUEST"}
+	ErrLighthouseRuntimeErroredDocumentRequest  = &LighthouseRuntimeError{Code: "ERRORED_DOCUMENT_REQUEST"}
+	ErrLighthouseRuntimeNoDocumentRequest       = &LighthouseRuntimeError{Code: "NO_DOCUMENT_REQUEST"}
+	ErrLighthouseRuntimeInsecureDocumentRequest = &LighthouseRuntimeError{Code: "INSECURE_DOCUMENT_REQUEST"}
+	ErrLighthouseRuntimeNotHTML                 = &LighthouseRuntimeError{Code: "NOT_HTML"}
+
+	// The page blocks or breaks Lighthouse
+	ErrLighthouseRuntimeChromeInterstitialError = &LighthouseRuntimeError{Code: "CHROME_INTERSTITIAL_ERROR"}
+	ErrLighthouseRuntimePageHung                = &LighthouseRuntimeError{Code: "PAGE_HUNG"}
+	ErrLighthouseRuntimeTargetCrashed           = &LighthouseRuntimeError{Code: "TARGET_CRASHED"}
+	ErrLighthouseRuntimeProtocolTimeout         = &LighthouseRuntimeError{Code: "PROTOCOL_TIMEOUT"}
+	ErrLighthouseRuntimeRequestContentTimeout   = &LighthouseRuntimeError{Code: "REQUEST_CONTENT_TIMEOUT"}
+
+	// The page loaded, but the metrics cannot be computed
+	ErrLighthouseRuntimeNoFCP                   = &LighthouseRuntimeError{Code: "NO_FCP"}
+	ErrLighthouseRuntimeNoLCP                   = &LighthouseRuntimeError{Code: "NO_LCP"}
+	ErrLighthouseRuntimeNoLCPAllFrames          = &LighthouseRuntimeError{Code: "NO_LCP_ALL_FRAMES"}
+	ErrLighthouseRuntimeNoFMP                   = &LighthouseRuntimeError{Code: "NO_FMP"}
+	ErrLighthouseRuntimeNoDCL                   = &LighthouseRuntimeError{Code: "NO_DCL"}
+	ErrLighthouseRuntimeNoNavstart              = &LighthouseRuntimeError{Code: "NO_NAVSTART"}
+	ErrLighthouseRuntimeNoSpeedlineFrames       = &LighthouseRuntimeError{Code: "NO_SPEEDLINE_FRAMES"}
+	ErrLighthouseRuntimeSpeedindexOfZero        = &LighthouseRuntimeError{Code: "SPEEDINDEX_OF_ZERO"}
+	ErrLighthouseRuntimeNoScreenshots           = &LighthouseRuntimeError{Code: "NO_SCREENSHOTS"}
+	ErrLighthouseRuntimeInvalidSpeedline        = &LighthouseRuntimeError{Code: "INVALID_SPEEDLINE"}
+	ErrLighthouseRuntimeNoTTICPUIdlePeriod      = &LighthouseRuntimeError{Code: "NO_TTI_CPU_IDLE_PERIOD"}
+	ErrLighthouseRuntimeNoTTINetworkIdlePeriod  = &LighthouseRuntimeError{Code: "NO_TTI_NETWORK_IDLE_PERIOD"}
+	ErrLighthouseRuntimeNoTracingStarted        = &LighthouseRuntimeError{Code: "NO_TRACING_STARTED"}
+	ErrLighthouseRuntimeNoResourceRequest       = &LighthouseRuntimeError{Code: "NO_RESOURCE_REQUEST"}
+	ErrLighthouseRuntimeTracingAlreadyStarted   = &LighthouseRuntimeError{Code: "TRACING_ALREADY_STARTED"}
+	ErrLighthouseRuntimeParsingProblem          = &LighthouseRuntimeError{Code: "PARSING_PROBLEM"}
+	ErrLighthouseRuntimeReadFailed              = &LighthouseRuntimeError{Code: "READ_FAILED"}
+	ErrLighthouseRuntimeMissingRequiredArtifact = &LighthouseRuntimeError{Code: "MISSING_REQUIRED_ARTIFACT"}
+	ErrLighthouseRuntimeErroredRequiredArtifact = &LighthouseRuntimeError{Code: "ERRORED_REQUIRED_ARTIFACT"}
+	ErrLighthouseRuntimeUnsupportedOldChrome    = &LighthouseRuntimeError{Code: "UNSUPPORTED_OLD_CHROME"}
+	ErrLighthouseRuntimeUnknownError            = &LighthouseRuntimeError{Code: "UNKNOWN_ERROR"}
+)
+
+// lighthouseCodeRegexp matches the code in the message of the *GoogleError (eg.: "Lighthouse returned error: NO_FCP. ...").
+var lighthouseCodeRegexp = regexp.MustCompile(`^Lighthouse returned error: ([A-Z_]+)\b`)
+
+// lighthouseCode returns the Lighthouse runtime error code from message.
+//
+// If message is not a Lighthouse runtime error, returns an empty string.
+func lighthouseCode(message string) string {
+
+	m := lighthouseCodeRegexp.FindStringSubmatch(message)
+	if m == nil {
+		return ""
+	}
+
+	return m[1]
+}
+
+func NewLighthouseRuntimeError(code, message string) *LighthouseRuntimeError {
+	return &LighthouseRuntimeError{Code: code, Message: message}
+}
+
+// Error returns the message or the code if the message is empty.
+func (e *LighthouseRuntimeError) Error() string {
+
+	if e.Message == "" {
+		return e.Code
+	}
+
+	return e.Message
+}
+
+// Is implements the [errors.Is].
+//
+// The Code fields must be equal.
+func (e *LighthouseRuntimeError) Is(target error) bool {
+
+	t, ok := target.(*LighthouseRuntimeError)
+	if !ok {
+		return false
+	}
+
+	return t.Code == e.Code
+}
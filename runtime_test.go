@@ -0,0 +1,69 @@
+package google_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+func TestLighthouseRuntimeError(t *testing.T) {
+
+	body := `{"lighthouseResult":{"requestedUrl":"https://example.com/","runtimeError":{"code":"NO_FCP","message":"The page did not paint any content."},"runWarnings":["The page loaded too slowly to finish within the time limit."]}}`
+
+	_, err := google.NewResponseDecoder(strings.NewReader(body)).DecodeLighthouseResult()
+	if !errors.Is(err, google.ErrLighthouseRuntimeNoFCP) {
+		t.Fatalf("FAIL: error is not ErrLighthouseRuntimeNoFCP: %v\n", err)
+	}
+
+	if errors.Is(err, google.ErrLighthouseRuntimeDNSFailure) {
+		t.Fatalf("FAIL: error is ErrLighthouseRuntimeDNSFailure\n")
+	}
+
+	var rerr *google.LighthouseRuntimeError
+	if !errors.As(err, &rerr) || len(rerr.Warnings) != 1 {
+		t.Fatalf("FAIL: invalid runtime error: %#v\n", err)
+	}
+
+	// Older LHRs
+	body = `{"lighthouseResult":{"requestedUrl":"https://example.com/","runtimeError":{"code":"NO_ERROR","message":""}}}`
+
+	if _, err = google.NewResponseDecoder(strings.NewReader(body)).DecodeLighthouseResult(); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+}
+
+func TestGoogleErrorIsLighthouseRuntimeError(t *testing.T) {
+
+	err := &google.Error{
+		Code:    500,
+		Message: "Lighthouse returned error: ERRORED_DOCUMENT_REQUEST. Lighthouse was unable to reliably load the page you requested. (Status code: 403)",
+		Errors: []error{
+			google.NewGoogleError("lighthouse", "lighthouseUserError", "Lighthouse returned error: ERRORED_DOCUMENT_REQUEST. Lighthouse was unable to reliably load the page you requested. (Status code: 403)", "", ""),
+		},
+	}
+
+	if !errors.Is(err, google.ErrLighthouseRuntimeErroredDocumentRequest) {
+		t.Fatalf("FAIL: error is not ErrLighthouseRuntimeErroredDocumentRequest\n")
+	}
+
+	if errors.Is(err, google.ErrLighthouseRuntimeFailedDocumentRequest) {
+		t.Fatalf("FAIL: error is ErrLighthouseRuntimeFailedDocumentRequest\n")
+	}
+}
+
+func TestLighthouseRuntimeErrorTimeouts(t *testing.T) {
+
+	body := `{"lighthouseResult":{"requestedUrl":"https://example.com/","runtimeError":{"code":"NO_LCP_ALL_FRAMES","message":"The page did not display content that qualifies as a Largest Contentful Paint (LCP)."}}}`
+
+	_, err := google.NewResponseDecoder(strings.NewReader(body)).DecodeLighthouseResult()
+	if !errors.Is(err, google.ErrLighthouseRuntimeNoLCPAllFrames) || errors.Is(err, google.ErrLighthouseRuntimeNoLCP) {
+		t.Fatalf("FAIL: error is not ErrLighthouseRuntimeNoLCPAllFrames: %v\n", err)
+	}
+
+	err = google.NewGoogleError("lighthouse", "lighthouseUserError", "Lighthouse returned error: REQUEST_CONTENT_TIMEOUT. Fetching resource content has exceeded the allotted time.", "", "")
+	if !errors.Is(err, google.ErrLighthouseRuntimeRequestContentTimeout) || !google.IsRetryable(err) {
+		t.Fatalf("FAIL: error is not a retryable ErrLighthouseRuntimeRequestContentTimeout: %v\n", err)
+	}
+}
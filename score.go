@@ -0,0 +1,255 @@
+package google
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// CategoryID is the id of a category in the LHR.
+type CategoryID string
+
+// The ids of the categories in the LHR
+const (
+	CategoryPerformance   CategoryID = "performance"
+	CategoryAccessibility CategoryID = "accessibility"
+	CategoryBestPractices CategoryID = "best-practices"
+	CategorySEO           CategoryID = "seo"
+)
+
+// NullScore is a score in the range of 0-1 that can be null.
+//
+// The score is null if it is not available (eg.: the audit errored, or the audit is informative or manual).
+type NullScore struct {
+	Value float64
+	Valid bool // Valid is true if the score is not null
+}
+
+// Percent returns the score in the range of 0-100, rounded the same way as in the Lighthouse report.
+//
+// If the score is null, returns 0 and false.
+func (s NullScore) Percent() (int, bool) {
+
+	if !s.Valid {
+		return 0, false
+	}
+
+	// Math.round() in JavaScript rounds half up
+	return int(math.Floor(s.Value*100 + 0.5)), true
+}
+
+func (s NullScore) MarshalJSON() ([]byte, error) {
+
+	if !s.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(s.Value)
+}
+
+func (s *NullScore) UnmarshalJSON(data []byte) error {
+
+	if bytes.Equal(data, []byte("null")) {
+		*s = NullScore{}
+		return nil
+	}
+
+	err := json.Unmarshal(data, &s.Value)
+	if err != nil {
+		return err
+	}
+
+	s.Valid = true
+
+	return nil
+}
+
+func (s NullScore) String() string {
+
+	if !s.Valid {
+		return "null"
+	}
+
+	return strconv.FormatFloat(s.Value, 'f', -1, 64)
+}
+
+// CategoryScore is the score of a category in a LighthouseResult.
+type CategoryScore struct {
+	ID    CategoryID
+	Score NullScore
+}
+
+// CategoryScore returns the score of the category with the given id.
+//
+// If the category is missing or its score is null, the returned score is not valid.
+func (r *LighthouseResult) CategoryScore(id CategoryID) NullScore {
+
+	c := r.categories[string(id)]
+	if c == nil {
+		return NullScore{}
+	}
+
+	return c.Score
+}
+
+// CategoryScores returns the scores of the available categories, sorted by id.
+func (r *LighthouseResult) CategoryScores() []CategoryScore {
+
+	v := make([]CategoryScore, 0, len(r.categories))
+
+	for k := range r.categories {
+		v = append(v, CategoryScore{ID: CategoryID(k), Score: r.categories[k].Score})
+	}
+
+	sort.Slice(v, func(i, j int) bool { return v[i].ID < v[j].ID })
+
+	return v
+}
+
+// ScoreWeights are the weights of the categories in [LighthouseResult.AverageScore].
+type ScoreWeights map[CategoryID]float64
+
+// AverageScore returns the weighted average score of the categories in weights.
+//
+// If weights is nil, returns the average of every available category with equal weights.
+// The missing categories, the categories with null score and the categories with a non-positive weight are skipped.
+// If no category is left, the returned score is not valid.
+func (r *LighthouseResult) AverageScore(weights ScoreWeights) NullScore {
+
+	if weights == nil {
+		weights = make(ScoreWeights, len(r.categories))
+		for k := range r.categories {
+			weights[CategoryID(k)] = 1
+		}
+	}
+
+	ids := make([]CategoryID, 0, len(weights))
+	for id := range weights {
+		ids = append(ids, id)
+	}
+
+	// The floating point sum depends on the order, so the ids are sorted to get the same result on every call
+	slices.Sort(ids)
+
+	var sum, total float64
+
+	for _, id := range ids {
+
+		w := weights[id]
+
+		s := r.CategoryScore(id)
+		if !s.Valid || w <= 0 {
+			continue
+		}
+
+		sum += s.Value * w
+		total += w
+	}
+
+	if total == 0 {
+		return NullScore{}
+	}
+
+	return NullScore{Value: sum / total, Valid: true}
+}
+
+// ScoreOptions changes the audits used by [LighthouseResult.RecomputeCategoryScore].
+type ScoreOptions struct {
+	Weights map[string]float64 // Overrides the weight of the audits by audit id
+	Exclude []string           // The ids of the audits to exclude (eg.: the third-party audits)
+}
+
+// AuditContribution is the contribution of an audit to the category score.
+type AuditContribution struct {
+	ID           string
+	Group        string    // The group of the audit in the category
+	Weight       float64   // The weight used in the calculation (0 if the audit is not scored)
+	Score        NullScore // The score of the audit
+	Contribution float64   // The amount of the category score earned by the audit (0-1)
+	Potential    float64   // The amount of the category score that the audit would add if it passed (0-1)
+}
+
+// CategoryBreakdown is the recomputed score of a category with the contribution of every audit.
+type CategoryBreakdown struct {
+	ID     CategoryID
+	Score  NullScore           // The recomputed score, rounded to 2 decimals like in the LHR
+	Audits []AuditContribution // Sorted by Potential, the audits that would move the score the most are the first
+}
+
+// RecomputeCategoryScore recomputes the score of the category from the scores and weights of its audits.
+//
+// The score is the weighted average of the audit scores, the same way as Lighthouse calculates it.
+// The not applicable and manual audits and the audits without score are weighted with 0.
+// If an audit with a positive weight errored, the category score is null.
+// The weights and the audits can be changed with opts (opts can be nil).
+//
+// If the category is missing, returns nil.
+func (r *LighthouseResult) RecomputeCategoryScore(id CategoryID, opts *ScoreOptions) *CategoryBreakdown {
+
+	c := r.categories[string(id)]
+	if c == nil {
+		return nil
+	}
+
+	if opts == nil {
+		opts = &ScoreOptions{}
+	}
+
+	b := &CategoryBreakdown{ID: id, Audits: make([]AuditContribution, 0, len(c.AuditRefs))}
+
+	var total, sum float64
+	var errored bool
+
+	for _, ref := range c.AuditRefs {
+
+		if slices.Contains(opts.Exclude, ref.ID) {
+			continue
+		}
+
+		v := AuditContribution{ID: ref.ID, Group: ref.Group, Weight: float64(ref.Weight)}
+
+		if w, ok := opts.Weights[ref.ID]; ok {
+			v.Weight = w
+		}
+
+		a := r.audits[ref.ID]
+
+		switch {
+		case a == nil:
+			v.Weight = 0
+		case a.ScoreDisplayMode == "notApplicable" || a.ScoreDisplayMode == "manual":
+			v.Weight = 0
+		case a.ScoreDisplayMode == "error" && v.Weight > 0:
+			errored = true
+			v.Weight = 0
+		default:
+			v.Score = a.Score
+			if !v.Score.Valid || v.Weight < 0 {
+				v.Weight = 0
+			}
+		}
+
+		total += v.Weight
+		sum += v.Weight * v.Score.Value
+
+		b.Audits = append(b.Audits, v)
+	}
+
+	if total > 0 {
+		for i := range b.Audits {
+			b.Audits[i].Contribution = b.Audits[i].Weight * b.Audits[i].Score.Value / total
+			b.Audits[i].Potential = b.Audits[i].Weight * (1 - b.Audits[i].Score.Value) / total
+		}
+	}
+
+	sort.SliceStable(b.Audits, func(i, j int) bool { return b.Audits[i].Potential > b.Audits[j].Potential })
+
+	if total > 0 && !errored {
+		b.Score = NullScore{Value: math.Round(sum/total*100) / 100, Valid: true}
+	}
+
+	return b
+}
@@ -0,0 +1,175 @@
+package google_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+func TestNullScorePercent(t *testing.T) {
+
+	cases := map[float64]int{0: 0, 0.125: 13, 0.285: 28, 0.5: 50, 0.99: 99, 0.995: 100, 1: 100}
+
+	for v, want := range cases {
+		if p, ok := (google.NullScore{Value: v, Valid: true}).Percent(); !ok || p != want {
+			t.Fatalf("FAIL: invalid percent of %v: %d, want %d\n", v, p, want)
+		}
+	}
+
+	if _, ok := (google.NullScore{}).Percent(); ok {
+		t.Fatalf("FAIL: null score is valid\n")
+	}
+}
+
+func TestAuditNullScore(t *testing.T) {
+
+	res := readTestResult(t, false)
+
+	if s := res.Audit("uses-http2").Score; s.Valid {
+		t.Fatalf("FAIL: score of an errored audit is not null: %s\n", s)
+	}
+
+	if s := res.Audit("unused-javascript").Score; !s.Valid || s.Value != 0 {
+		t.Fatalf("FAIL: invalid zero score: %s\n", s)
+	}
+
+	if s := res.Audit("largest-contentful-paint").Score; s.Value != 0.76 {
+		t.Fatalf("FAIL: score is not precise: %s\n", s)
+	}
+
+	data, err := json.Marshal(res.Audit("uses-http2"))
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if !strings.Contains(string(data), `"score":null`) {
+		t.Fatalf("FAIL: null score is not written: %s\n", data)
+	}
+}
+
+func TestCategoryScores(t *testing.T) {
+
+	res := readTestResult(t, false)
+
+	if s := res.CategoryScore(google.CategoryAccessibility); s.Value != 0.88 {
+		t.Fatalf("FAIL: invalid accessibility score: %s\n", s)
+	}
+
+	if s := res.CategoryScore("pwa"); s.Valid {
+		t.Fatalf("FAIL: missing category is valid: %s\n", s)
+	}
+
+	scores := res.CategoryScores()
+	if len(scores) != 4 || scores[0].ID != google.CategoryAccessibility || scores[3].ID != google.CategorySEO {
+		t.Fatalf("FAIL: invalid category scores: %v\n", scores)
+	}
+
+	if p, _ := res.AverageScore(nil).Percent(); p != 90 {
+		t.Fatalf("FAIL: invalid average score: %d\n", p)
+	}
+
+	if s := res.AverageScore(google.ScoreWeights{google.CategoryPerformance: 3, google.CategorySEO: 1}); s.Value < 0.9024 || s.Value > 0.9026 {
+		t.Fatalf("FAIL: invalid weighted average score: %s\n", s)
+	}
+
+	if s := res.AverageScore(google.ScoreWeights{"pwa": 1}); s.Valid {
+		t.Fatalf("FAIL: average of missing categories is valid: %s\n", s)
+	}
+}
+
+func TestScoreNullCategory(t *testing.T) {
+
+	report := `{"lighthouseVersion":"12.0.0","categories":{"performance":{"id":"performance","score":null},"seo":{"id":"seo","score":0.994}}}`
+
+	res, err := google.LoadLighthouseResult(strings.NewReader(report))
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if s := res.CategoryScore(google.CategoryPerformance); s.Valid {
+		t.Fatalf("FAIL: null score is valid: %s\n", s)
+	}
+
+	if res.Score("performance") != 0 || res.Score("seo") != 99 || res.Score("average") != 99 || res.Score("total") != 99 || res.Score("pwa") != -1 {
+		t.Fatalf("FAIL: invalid scores: %d %d %d %d\n", res.Score("performance"), res.Score("seo"), res.Score("average"), res.Score("total"))
+	}
+}
+
+func TestRecomputeCategoryScore(t *testing.T) {
+
+	res := readTestResult(t, false)
+
+	b := res.RecomputeCategoryScore(google.CategoryPerformance, nil)
+	if b == nil || b.Score.Value != 0.89 {
+		t.Fatalf("FAIL: invalid performance score: %#v\n", b)
+	}
+
+	if b.Audits[0].ID != "largest-contentful-paint" || b.Audits[0].Potential < 0.0599 || b.Audits[0].Potential > 0.0601 {
+		t.Fatalf("FAIL: invalid first audit: %#v\n", b.Audits[0])
+	}
+
+	var sum float64
+	for _, a := range b.Audits {
+		sum += a.Contribution
+	}
+
+	if sum < 0.8929 || sum > 0.8931 {
+		t.Fatalf("FAIL: invalid sum of contributions: %v\n", sum)
+	}
+
+	// Only TBT is left
+	b = res.RecomputeCategoryScore(google.CategoryPerformance, &google.ScoreOptions{
+		Weights: map[string]float64{"first-contentful-paint": 0, "speed-index": 0},
+		Exclude: []string{"largest-contentful-paint", "cumulative-layout-shift"},
+	})
+
+	if b.Score.Value != 0.99 || len(b.Audits) != 14 {
+		t.Fatalf("FAIL: invalid performance score with options: %s, %d audits\n", b.Score, len(b.Audits))
+	}
+
+	// The errored audit is weighted
+	b = res.RecomputeCategoryScore(google.CategoryBestPractices, &google.ScoreOptions{Weights: map[string]float64{"uses-http2": 1}})
+	if b.Score.Valid {
+		t.Fatalf("FAIL: score with errored audit is not null: %s\n", b.Score)
+	}
+
+	if res.RecomputeCategoryScore("pwa", nil) != nil {
+		t.Fatalf("FAIL: missing category is not nil\n")
+	}
+}
+
+func TestAuditScoreManual(t *testing.T) {
+
+	// A hand built audit can express the null score
+	a := &google.Audit{ID: "manual-audit", ScoreDisplayMode: "manual"}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if !strings.Contains(string(data), `"score":null`) {
+		t.Fatalf("FAIL: null score is not written: %s\n", data)
+	}
+
+	a.Score = google.NullScore{Value: 0.5, Valid: true}
+
+	if data, err = json.Marshal(a); err != nil || !strings.Contains(string(data), `"score":0.5`) {
+		t.Fatalf("FAIL: invalid score: %s, %v\n", data, err)
+	}
+}
+
+func TestAverageScoreStable(t *testing.T) {
+
+	res := readTestResult(t, false)
+
+	want := res.AverageScore(nil)
+
+	for i := 0; i < 100; i++ {
+		if s := res.AverageScore(nil); s != want {
+			t.Fatalf("FAIL: average score changed: %s, want %s\n", s, want)
+		}
+	}
+}
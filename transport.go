@@ -0,0 +1,168 @@
+package google
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+)
+
+// Proxy selects the proxy URL for the requests.
+//
+// The zero value uses no proxy.
+type Proxy struct {
+	urls   []*url.URL
+	next   int
+	random bool
+	m      sync.Mutex
+}
+
+// TransportConfig stores the egress configuration of the HTTP transport.
+type TransportConfig struct {
+	Proxy        *Proxy            // If nil, the proxy is set from the environment (see [http.ProxyFromEnvironment]).
+	RootCAs      *x509.CertPool    // If nil, the system pool is used.
+	Certificates []tls.Certificate // Client certificates.
+}
+
+// parseProxyURLs parses the proxy URLs, every URL must have a scheme and a host.
+func parseProxyURLs(urls ...string) ([]*url.URL, error) {
+
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no proxy URL")
+	}
+
+	v := make([]*url.URL, 0, len(urls))
+
+	for i := range urls {
+
+		u, err := url.Parse(urls[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %q: %w", urls[i], err)
+		}
+
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy %q: missing scheme or host", urls[i])
+		}
+
+		v = append(v, u)
+	}
+
+	return v, nil
+}
+
+// NewProxy returns a Proxy that uses u for every request.
+func NewProxy(u string) (*Proxy, error) {
+
+	urls, err := parseProxyURLs(u)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Proxy{urls: urls, random: false}, nil
+}
+
+// RandomProxies returns a Proxy that selects a random proxy for every request.
+//
+// Returns an error if urls is empty or any URL has no scheme or host.
+func RandomProxies(urls ...string) (*Proxy, error) {
+
+	v, err := parseProxyURLs(urls...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Proxy{urls: v, random: true}, nil
+}
+
+// RotatingProxies returns a Proxy that selects the next proxy for every request, starting with the first one.
+//
+// Returns an error if urls is empty or any URL has no scheme or host.
+func RotatingProxies(urls ...string) (*Proxy, error) {
+
+	v, err := parseProxyURLs(urls...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Proxy{urls: v, random: false}, nil
+}
+
+// URL returns the proxy URL for req.
+//
+// The signature matches [http.Transport.Proxy].
+// Returns nil (no proxy), if no proxy URL added.
+func (p *Proxy) URL(req *http.Request) (*url.URL, error) {
+
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if len(p.urls) == 0 {
+		return nil, nil
+	}
+
+	if len(p.urls) == 1 {
+		return p.urls[0], nil
+	}
+
+	if p.random {
+		return p.urls[rand.Intn(len(p.urls))], nil
+	}
+
+	u := p.urls[p.next]
+
+	p.next = (p.next + 1) % len(p.urls)
+
+	return u, nil
+}
+
+// LoadRootCAs returns a certificate pool of the system roots and the PEM encoded certificates in files.
+func LoadRootCAs(files ...string) (*x509.CertPool, error) {
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	for i := range files {
+
+		data, err := os.ReadFile(files[i])
+		if err != nil {
+			return nil, fmt.Errorf("read error: %w", err)
+		}
+
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificate found in %s", files[i])
+		}
+	}
+
+	return pool, nil
+}
+
+// NewTransport returns a clone of [http.DefaultTransport] configured with c.
+func NewTransport(c TransportConfig) *http.Transport {
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if c.Proxy != nil {
+		t.Proxy = c.Proxy.URL
+	}
+
+	if c.RootCAs != nil || len(c.Certificates) > 0 {
+		t.TLSClientConfig = &tls.Config{
+			RootCAs:      c.RootCAs,
+			Certificates: c.Certificates,
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+
+	return t
+}
+
+// NewClientWithTransport returns a Client that uses a transport configured with c.
+func NewClientWithTransport(c TransportConfig) *Client {
+	return NewClient(&http.Client{Transport: NewTransport(c)})
+}
@@ -0,0 +1,224 @@
+package google_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g0rbe/go-google"
+)
+
+func TestRotatingProxies(t *testing.T) {
+
+	p, err := google.RotatingProxies("http://proxy-one:3128", "http://proxy-two:3128")
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	var res []string
+
+	for i := 0; i < 4; i++ {
+
+		u, err := p.URL(nil)
+		if err != nil {
+			t.Fatalf("FAIL: %s\n", err)
+		}
+
+		res = append(res, u.Host)
+	}
+
+	if strings.Join(res, ",") != "proxy-one:3128,proxy-two:3128,proxy-one:3128,proxy-two:3128" {
+		t.Fatalf("FAIL: invalid order: %v\n", res)
+	}
+}
+
+func TestProxyInvalid(t *testing.T) {
+
+	if _, err := google.RotatingProxies(); err == nil {
+		t.Fatalf("FAIL: no error for empty rotating proxies\n")
+	}
+
+	if _, err := google.RandomProxies(); err == nil {
+		t.Fatalf("FAIL: no error for empty random proxies\n")
+	}
+
+	for _, u := range []string{"proxy-one:3128", "//proxy-one:3128", "http://", "/proxy"} {
+		if _, err := google.NewProxy(u); err == nil {
+			t.Fatalf("FAIL: no error for %q\n", u)
+		}
+	}
+
+	// The zero value uses no proxy
+	if u, err := new(google.Proxy).URL(nil); u != nil || err != nil {
+		t.Fatalf("FAIL: zero Proxy returned %v, %v\n", u, err)
+	}
+}
+
+func TestNewClientWithTransportProxy(t *testing.T) {
+
+	// The proxy responds to the proxied request directly
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if r.URL.Host != "www.googleapis.com" {
+			http.Error(w, "invalid host", http.StatusBadGateway)
+			return
+		}
+
+		io.WriteString(w, `{"lighthouseResult":{"requestedUrl":"`+r.URL.Query().Get("url")+`"}}`)
+	}))
+	defer proxy.Close()
+
+	p, err := google.NewProxy(proxy.URL)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	c := google.NewClientWithTransport(google.TransportConfig{Proxy: p})
+
+	req, err := http.NewRequest(http.MethodGet, "http://www.googleapis.com/pagespeedonline/v5/runPagespeed?url=https://example.com/", nil)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+	defer resp.Body.Close()
+
+	res, err := google.LighthouseResultFromResponse(resp)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	if res.RequestedURL().String() != "https://example.com/" {
+		t.Fatalf("FAIL: invalid RequestedURL: %s\n", res.RequestedURL())
+	}
+}
+
+// testTLSHandler responds with a minimal PageSpeed response.
+func testTLSHandler(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, `{"lighthouseResult":{"requestedUrl":"https://example.com/"}}`)
+}
+
+// testGet sends a GET request to u with c.
+func testGet(c *google.Client, u string) (*http.Response, error) {
+
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(req)
+}
+
+// testClientCertificate returns a self-signed client certificate.
+func testClientCertificate(t *testing.T) tls.Certificate {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestNewClientWithTransportRootCAs(t *testing.T) {
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(testTLSHandler))
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0) // The failed handshake is expected
+	srv.StartTLS()
+	defer srv.Close()
+
+	// The server certificate is not trusted by the system pool
+	if _, err := testGet(google.NewClientWithTransport(google.TransportConfig{}), srv.URL); err == nil {
+		t.Fatalf("FAIL: untrusted certificate is accepted\n")
+	}
+
+	name := filepath.Join(t.TempDir(), "ca.pem")
+
+	if err := os.WriteFile(name, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0600); err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	pool, err := google.LoadRootCAs(name)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	resp, err := testGet(google.NewClientWithTransport(google.TransportConfig{RootCAs: pool}), srv.URL)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+	resp.Body.Close()
+
+	if _, err = google.LoadRootCAs(filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Fatalf("FAIL: no error for missing file\n")
+	}
+}
+
+func TestNewClientWithTransportClientCertificate(t *testing.T) {
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "client" {
+			http.Error(w, "invalid client certificate", http.StatusForbidden)
+			return
+		}
+
+		testTLSHandler(w, r)
+	}))
+
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0) // The failed handshake is expected
+	srv.StartTLS()
+	defer srv.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	// The handshake fails without client certificate
+	if _, err := testGet(google.NewClientWithTransport(google.TransportConfig{RootCAs: pool}), srv.URL); err == nil {
+		t.Fatalf("FAIL: no error without client certificate\n")
+	}
+
+	c := google.NewClientWithTransport(google.TransportConfig{RootCAs: pool, Certificates: []tls.Certificate{testClientCertificate(t)}})
+
+	resp, err := testGet(c, srv.URL)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("FAIL: invalid status: %d\n", resp.StatusCode)
+	}
+}
@@ -0,0 +1,168 @@
+package google
+
+import (
+	"time"
+)
+
+// VitalStatus is the status of a Core Web Vital.
+type VitalStatus string
+
+// Possible values of VitalStatus
+const (
+	VitalGood             VitalStatus = "good"
+	VitalNeedsImprovement VitalStatus = "needs-improvement"
+	VitalPoor             VitalStatus = "poor"
+	VitalMissing          VitalStatus = "missing" // Not enough data
+)
+
+// VitalsSource is the source of the data used in the assessment.
+type VitalsSource string
+
+// Possible values of VitalsSource
+const (
+	VitalsSourcePage   VitalsSource = "page"   // Field data of the page (loadingExperience)
+	VitalsSourceOrigin VitalsSource = "origin" // Field data of the origin (originLoadingExperience)
+	VitalsSourceLab    VitalsSource = "lab"    // Lab data of Lighthouse
+	VitalsSourceNone   VitalsSource = "none"   // No data
+)
+
+// VitalThreshold is the upper bound of the good and the needs improvement range of a metric.
+type VitalThreshold struct {
+	Good float64
+	Poor float64 // Values above Poor are poor
+}
+
+// The thresholds of the Core Web Vitals
+//
+// See: https://web.dev/articles/defining-core-web-vitals-thresholds
+var (
+	ThresholdLCP = VitalThreshold{Good: 2500, Poor: 4000} // Milliseconds
+	ThresholdINP = VitalThreshold{Good: 200, Poor: 500}   // Milliseconds
+	ThresholdCLS = VitalThreshold{Good: 0.1, Poor: 0.25}  // Unitless
+	ThresholdTBT = VitalThreshold{Good: 200, Poor: 600}   // Milliseconds, the lab proxy of INP
+)
+
+// Status returns the status of v.
+func (t VitalThreshold) Status(v float64) VitalStatus {
+
+	switch {
+	case v <= t.Good:
+		return VitalGood
+	case v <= t.Poor:
+		return VitalNeedsImprovement
+	default:
+		return VitalPoor
+	}
+}
+
+// VitalAssessment is the assessment of a single metric.
+type VitalAssessment struct {
+	Metric string      // "LCP", "INP", "CLS" or "TBT" (lab proxy of INP)
+	Value  float64     // The 75th percentile of the field data or the lab value; milliseconds or unitless (CLS)
+	Status VitalStatus //
+}
+
+// VitalsAssessment is the Core Web Vitals assessment.
+type VitalsAssessment struct {
+	Passed bool         // LCP, INP and CLS are good (or INP is missing)
+	Source VitalsSource // The data used in the assessment
+	LCP    VitalAssessment
+	INP    VitalAssessment // TBT if Source is VitalsSourceLab
+	CLS    VitalAssessment
+}
+
+// fieldVital returns the assessment of the field metric.
+func fieldVital(e *LoadingExperience, metric, key string, t VitalThreshold, scale float64) VitalAssessment {
+
+	m := e.Metric(key)
+	if m == nil || m.Category == FieldCategoryNone {
+		return VitalAssessment{Metric: metric, Status: VitalMissing}
+	}
+
+	v := m.Percentile / scale
+
+	return VitalAssessment{Metric: metric, Value: v, Status: t.Status(v)}
+}
+
+// fieldVitals returns the assessment of the field data.
+//
+// If LCP or CLS is missing, returns nil.
+func fieldVitals(e *LoadingExperience, source VitalsSource) *VitalsAssessment {
+
+	v := &VitalsAssessment{
+		Source: source,
+		LCP:    fieldVital(e, "LCP", FieldLargestContentfulPaint, ThresholdLCP, 1),
+		INP:    fieldVital(e, "INP", FieldInteractionToNextPaint, ThresholdINP, 1),
+		CLS:    fieldVital(e, "CLS", FieldCumulativeLayoutShift, ThresholdCLS, 100),
+	}
+
+	if v.LCP.Status == VitalMissing || v.CLS.Status == VitalMissing {
+		return nil
+	}
+
+	return v
+}
+
+// labVitals returns the assessment of the lab data.
+//
+// If LCP or CLS is missing, returns nil.
+func labVitals(r *LighthouseResult) *VitalsAssessment {
+
+	m := r.LabMetrics()
+
+	if m.LargestContentfulPaint == nil || m.CumulativeLayoutShift == nil {
+		return nil
+	}
+
+	lcp := float64(m.LargestContentfulPaint.Value) / float64(time.Millisecond)
+
+	v := &VitalsAssessment{
+		Source: VitalsSourceLab,
+		LCP:    VitalAssessment{Metric: "LCP", Value: lcp, Status: ThresholdLCP.Status(lcp)},
+		INP:    VitalAssessment{Metric: "TBT", Status: VitalMissing},
+		CLS:    VitalAssessment{Metric: "CLS", Value: m.CumulativeLayoutShift.Value, Status: ThresholdCLS.Status(m.CumulativeLayoutShift.Value)},
+	}
+
+	if m.TotalBlockingTime != nil {
+		tbt := float64(m.TotalBlockingTime.Value) / float64(time.Millisecond)
+		v.INP = VitalAssessment{Metric: "TBT", Value: tbt, Status: ThresholdTBT.Status(tbt)}
+	}
+
+	return v
+}
+
+// AssessCoreWebVitals returns whether the page passes the Core Web Vitals assessment.
+//
+// The field data of the page is used, if not available, the field data of the origin, and finally the lab data.
+// The assessment passes if the 75th percentile of LCP, INP and CLS are good.
+// If INP is missing (not enough data), the assessment is based on LCP and CLS.
+// In the lab data, TBT is used as the proxy of INP.
+func (r *PageSpeedResponse) AssessCoreWebVitals() *VitalsAssessment {
+
+	var v *VitalsAssessment
+
+	if r.LoadingExperience != nil && !r.LoadingExperience.OriginFallback {
+		v = fieldVitals(r.LoadingExperience, VitalsSourcePage)
+	}
+
+	if v == nil {
+		v = fieldVitals(r.OriginLoadingExperience, VitalsSourceOrigin)
+	}
+
+	if v == nil && r.LighthouseResult != nil {
+		v = labVitals(r.LighthouseResult)
+	}
+
+	if v == nil {
+		return &VitalsAssessment{
+			Source: VitalsSourceNone,
+			LCP:    VitalAssessment{Metric: "LCP", Status: VitalMissing},
+			INP:    VitalAssessment{Metric: "INP", Status: VitalMissing},
+			CLS:    VitalAssessment{Metric: "CLS", Status: VitalMissing},
+		}
+	}
+
+	v.Passed = v.LCP.Status == VitalGood && v.CLS.Status == VitalGood && (v.INP.Status == VitalGood || v.INP.Status == VitalMissing)
+
+	return v
+}
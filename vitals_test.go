@@ -0,0 +1,75 @@
+package google_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+func readTestPageSpeedResponse(t *testing.T) *google.PageSpeedResponse {
+
+	f, err := os.Open("testdata/pagespeed.json")
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+	defer f.Close()
+
+	res, err := google.NewResponseDecoder(f).DecodePageSpeedResponse()
+	if err != nil {
+		t.Fatalf("FAIL: %s\n", err)
+	}
+
+	return res
+}
+
+func TestAssessCoreWebVitals(t *testing.T) {
+
+	res := readTestPageSpeedResponse(t)
+
+	v := res.AssessCoreWebVitals()
+
+	if !v.Passed || v.Source != google.VitalsSourcePage {
+		t.Fatalf("FAIL: invalid page assessment: %#v\n", v)
+	}
+
+	if v.CLS.Value != 0.05 || v.CLS.Status != google.VitalGood || v.INP.Value != 180 {
+		t.Fatalf("FAIL: invalid page metrics: %#v\n", v)
+	}
+
+	// Origin: LCP needs improvement
+	res.LoadingExperience = nil
+
+	v = res.AssessCoreWebVitals()
+
+	if v.Passed || v.Source != google.VitalsSourceOrigin || v.LCP.Status != google.VitalNeedsImprovement {
+		t.Fatalf("FAIL: invalid origin assessment: %#v\n", v)
+	}
+
+	// Lab: LCP needs improvement, TBT as INP
+	res.OriginLoadingExperience = nil
+
+	v = res.AssessCoreWebVitals()
+
+	if v.Passed || v.Source != google.VitalsSourceLab || v.LCP.Status != google.VitalNeedsImprovement || v.INP.Metric != "TBT" || v.INP.Status != google.VitalGood {
+		t.Fatalf("FAIL: invalid lab assessment: %#v\n", v)
+	}
+
+	// No data
+	res.LighthouseResult = nil
+
+	v = res.AssessCoreWebVitals()
+
+	if v.Passed || v.Source != google.VitalsSourceNone || v.LCP.Status != google.VitalMissing {
+		t.Fatalf("FAIL: invalid assessment without data: %#v\n", v)
+	}
+}
+
+func TestVitalThresholdStatus(t *testing.T) {
+
+	if google.ThresholdLCP.Status(2500) != google.VitalGood ||
+		google.ThresholdLCP.Status(4000) != google.VitalNeedsImprovement ||
+		google.ThresholdLCP.Status(4001) != google.VitalPoor {
+		t.Fatalf("FAIL: invalid LCP status\n")
+	}
+}
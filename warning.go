@@ -0,0 +1,58 @@
+package google
+
+import (
+	"regexp"
+)
+
+// RunWarningCategory is the category of a RunWarning.
+type RunWarningCategory string
+
+// Possible values of RunWarningCategory
+const (
+	RunWarningUnknown      RunWarningCategory = "unknown"
+	RunWarningRedirected   RunWarningCategory = "redirected"   // The requested URL was redirected
+	RunWarningSlowPageLoad RunWarningCategory = "slowPageLoad" // The page loaded too slowly, results may be incomplete
+	RunWarningExtensions   RunWarningCategory = "extensions"   // Browser extensions affected the performance
+	RunWarningThrottling   RunWarningCategory = "throttling"   // The CPU or network throttling is not reliable
+	RunWarningStoredData   RunWarningCategory = "storedData"   // Stored data (eg.: IndexedDB) affected the performance
+	RunWarningNotPainted   RunWarningCategory = "notPainted"   // The page did not paint content or the content is not visible
+)
+
+// runWarningPatterns are used to categorize the run warnings, the first match wins.
+var runWarningPatterns = []struct {
+	c RunWarningCategory
+	r *regexp.Regexp
+}{
+	{RunWarningRedirected, regexp.MustCompile(`(?i)\bwas redirected to\b`)},
+	{RunWarningSlowPageLoad, regexp.MustCompile(`(?i)\b(loaded too slowly|took too long)\b`)},
+	{RunWarningExtensions, regexp.MustCompile(`(?i)\bextensions? negatively affected\b`)},
+	{RunWarningThrottling, regexp.MustCompile(`(?i)(throttl|slower CPU than Lighthouse expects)`)},
+	{RunWarningStoredData, regexp.MustCompile(`(?i)\bstored data affecting loading performance\b`)},
+	{RunWarningNotPainted, regexp.MustCompile(`(?i)\b(did not paint any content|not visible)\b`)},
+}
+
+// RunWarning is a non-fatal warning of the Lighthouse run.
+type RunWarning struct {
+	Category RunWarningCategory
+	Text     string // The raw warning text
+}
+
+// NewRunWarning returns a RunWarning with the category of text.
+func NewRunWarning(text string) *RunWarning {
+
+	c := RunWarningUnknown
+
+	for i := range runWarningPatterns {
+		if runWarningPatterns[i].r.MatchString(text) {
+			c = runWarningPatterns[i].c
+			break
+		}
+	}
+
+	return &RunWarning{Category: c, Text: text}
+}
+
+// Error returns the raw warning text.
+func (w *RunWarning) Error() string {
+	return w.Text
+}
@@ -0,0 +1,35 @@
+package google_test
+
+import (
+	"testing"
+
+	"github.com/g0rbe/go-google"
+)
+
+func TestNewRunWarning(t *testing.T) {
+
+	tests := []struct {
+		text     string
+		category google.RunWarningCategory
+	}{
+		{"The page may not be loading as expected because your test URL (https://example.com/) was redirected to https://www.example.com/. Try testing the second URL directly.", google.RunWarningRedirected},
+		{"The page loaded too slowly to finish within the time limit. Results may be incomplete.", google.RunWarningSlowPageLoad},
+		{"Chrome extensions negatively affected this page's load performance. Try auditing the page in incognito mode or from a Chrome profile without extensions.", google.RunWarningExtensions},
+		{"The tested device appears to have a slower CPU than Lighthouse expects. This can negatively affect your performance score.", google.RunWarningThrottling},
+		{"There may be stored data affecting loading performance in this location: IndexedDB. Audit this page in an incognito window to prevent those resources from affecting your scores.", google.RunWarningStoredData},
+		{"100% of the requests failed", google.RunWarningUnknown},
+	}
+
+	for _, tt := range tests {
+
+		w := google.NewRunWarning(tt.text)
+
+		if w.Category != tt.category {
+			t.Errorf("FAIL: invalid category of %q: %s\n", tt.text, w.Category)
+		}
+
+		if w.Error() != tt.text {
+			t.Errorf("FAIL: invalid text: %s\n", w.Error())
+		}
+	}
+}